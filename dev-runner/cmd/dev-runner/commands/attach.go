@@ -6,22 +6,33 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/google/subcommands"
+	cryptossh "golang.org/x/crypto/ssh"
 
+	"dev-runner/pkg/cli"
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/ports"
+	"dev-runner/pkg/dev/state"
 	"dev-runner/pkg/ssh"
 
+	devlabels "dev-runner/pkg/dev/labels"
 	fp "dev-runner/pkg/filepath"
 )
 
 type AttachCmd struct {
-	imageTag        string
-	hostWorkDirPath string
-	hostHomeDir     string
-	host            string
-	port            int
-	user            string
-	password        string
+	containerManagerName string
+	connection           connectionFlags
+	imageTag             string
+	hostWorkDirPath      string
+	hostHomeDir          string
+	host                 string
+	port                 int
+	user                 string
+	password             string
+	identityFiles        cli.StringSlice
+	useAgent             bool
 }
 
 func (*AttachCmd) Name() string {
@@ -41,13 +52,17 @@ func (p *AttachCmd) SetFlags(f *flag.FlagSet) {
 	workDir, _ := os.Getwd()
 	homeDir, _ := os.UserHomeDir()
 
+	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
 	f.StringVar(&p.imageTag, "image", "", "Dev image tag.")
 	f.StringVar(&p.hostWorkDirPath, "workDir", workDir, "Work dir on host to mount inside container.")
 	f.StringVar(&p.hostHomeDir, "homeDir", homeDir, "Home dir to use SSH.")
 	f.StringVar(&p.host, "host", "localhost", "The host to bind containers ports to.")
-	f.IntVar(&p.port, "port", 2221, "The SSH port to bind from container.")
+	f.IntVar(&p.port, "port", 0, "The SSH port of container. Default: port registered for the container by 'run'.")
 	f.StringVar(&p.user, "user", "user", "The container user username.")
-	f.StringVar(&p.password, "password", "user", "The container user password.")
+	f.StringVar(&p.password, "password", "user", "The container user password, it is used when SSH keys are rejected.")
+	f.Var(&p.identityFiles, "identity", "SSH private key file, can be set multiple times.")
+	f.BoolVar(&p.useAgent, "agent", true, "Use ssh-agent from 'SSH_AUTH_SOCK'.")
 }
 
 func (p *AttachCmd) validateCliArguments() (err error) {
@@ -63,13 +78,51 @@ func (p *AttachCmd) validateCliArguments() (err error) {
 	return nil
 }
 
-func (p *AttachCmd) execute(_ context.Context, _ *flag.FlagSet) (err error) {
+func (p *AttachCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
 	err = p.validateCliArguments()
 	if err != nil {
 		return fmt.Errorf("command line validation failed: %w", err)
 	}
 
-	err = ssh.RunShell(p.host, p.port, p.user, p.password)
+	var manager management.ContainerManager
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
+	if err != nil {
+		return fmt.Errorf("cannot create container manager: %w", err)
+	}
+
+	err = manager.Init(ctx)
+	if err != nil {
+		return fmt.Errorf("container manager initialization failed: %w", err)
+	}
+
+	// Containers of the previous naming scheme are attached to as well.
+	var containerName string
+	containerName, err = findContainerName(ctx, manager, p.imageTag, p.hostWorkDirPath)
+	if err != nil {
+		return fmt.Errorf("cannot find container: %w", err)
+	}
+
+	port := p.port
+	if port == 0 {
+		port, err = lookupSshPort(ctx, manager, containerName)
+		if err != nil {
+			return err
+		}
+	}
+
+	var authMethods []cryptossh.AuthMethod
+	authMethods, err = p.getAuthMethods(containerName)
+	if err != nil {
+		return fmt.Errorf("cannot get SSH authentication methods: %w", err)
+	}
+
+	var knownHosts *ssh.KnownHosts
+	knownHosts, err = loadKnownHosts()
+	if err != nil {
+		return err
+	}
+
+	err = ssh.RunShell(p.host, port, p.user, authMethods, knownHosts.HostKeyCallback(containerName))
 	if err != nil {
 		return fmt.Errorf("failed to run shell in container: %w", err)
 	}
@@ -85,3 +138,61 @@ func (p *AttachCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface
 	}
 	return subcommands.ExitSuccess
 }
+
+func (p *AttachCmd) getAuthMethods(containerName string) (authMethods []cryptossh.AuthMethod, err error) {
+	identityFiles := p.identityFiles.StringSlice
+
+	// The key generated by 'run' for the container is used when it exists.
+	var containerStateDir string
+	containerStateDir, err = state.ContainerDir(containerName)
+	if err != nil {
+		return nil, err
+	}
+	containerKeyPair := ssh.KeyPairPaths(containerStateDir)
+	if fp.IsFile(containerKeyPair.PrivateKeyPath) {
+		identityFiles = append(identityFiles, containerKeyPair.PrivateKeyPath)
+	}
+
+	return ssh.GetAuthMethods(ssh.AuthOptions{
+		IdentityFiles: identityFiles,
+		HomeDir:       p.hostHomeDir,
+		UseAgent:      p.useAgent,
+		Password:      p.password,
+	})
+}
+
+// lookupSshPort returns the port registered for the container by 'run',
+// containers which are not registered are looked up by their SSH port label.
+func lookupSshPort(ctx context.Context, manager management.ContainerManager, containerName string) (port int, err error) {
+	var registry *ports.Registry
+	registry, err = ports.LoadDefaultRegistry()
+	if err != nil {
+		return 0, err
+	}
+
+	port, ok := registry.Lookup(containerName)
+	if ok {
+		return port, nil
+	}
+
+	var containers []management.ContainerInfo
+	containers, err = manager.ListContainers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list containers failed: %w", err)
+	}
+	for _, info := range containers {
+		if info.Name != containerName {
+			continue
+		}
+		value, ok := info.GetLabel(devlabels.SshPort)
+		if !ok {
+			break
+		}
+		port, err = strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("incorrect SSH port label '%s' of container '%s': %w", value, containerName, err)
+		}
+		return port, nil
+	}
+	return 0, fmt.Errorf("SSH port of container '%s' is not registered, set 'port' explicitly", containerName)
+}
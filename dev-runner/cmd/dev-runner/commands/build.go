@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/subcommands"
+
+	"dev-runner/pkg/cli"
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/catalog"
+	"dev-runner/pkg/dev/catalog/hub"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+type BuildCmd struct {
+	containerManagerName string
+	connection           connectionFlags
+	catalogPath          string
+	imageNames           cli.StringSlice
+	all                  bool
+	variables            cli.StringSlice
+}
+
+func (*BuildCmd) Name() string {
+	return "build"
+}
+
+func (*BuildCmd) Synopsis() string {
+	return "build."
+}
+
+func (*BuildCmd) Usage() string {
+	return `
+`
+}
+
+func (p *BuildCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
+	f.StringVar(&p.catalogPath, "catalog", "catalog.textproto", "Build catalog file.")
+	f.Var(&p.imageNames, "image", "Catalog image name to build. Can be repeated.")
+	f.BoolVar(&p.all, "all", false, "Build all catalog images.")
+	f.Var(&p.variables, "var", "Catalog variable override in form 'NAME=VALUE'. Can be repeated.")
+}
+
+func (p *BuildCmd) validateCliArguments() (err error) {
+	if !fp.IsFile(p.catalogPath) {
+		return fmt.Errorf("'catalog' must be exists and be file")
+	}
+	if len(p.imageNames.StringSlice) == 0 && !p.all {
+		return fmt.Errorf("'image' or 'all' must be set")
+	}
+	for _, item := range p.variables.StringSlice {
+		if !strings.Contains(item, "=") {
+			return fmt.Errorf("'var' must be in form 'NAME=VALUE'")
+		}
+	}
+	return nil
+}
+
+func (p *BuildCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
+	err = p.validateCliArguments()
+	if err != nil {
+		return fmt.Errorf("command line validation failed: %w", err)
+	}
+
+	var c *hub.Catalog
+	c, err = catalog.LoadFile(p.catalogPath)
+	if err != nil {
+		return err
+	}
+
+	var images []*hub.Image
+	images, err = p.getImages(c)
+	if err != nil {
+		return err
+	}
+
+	overrides := make(map[string]string, len(p.variables.StringSlice))
+	for _, item := range p.variables.StringSlice {
+		name, value, _ := strings.Cut(item, "=")
+		overrides[name] = value
+	}
+	vars := catalog.GetVariables(c, overrides)
+
+	var manager management.ContainerManager
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
+	if err != nil {
+		return fmt.Errorf("cannot create container manager: %w", err)
+	}
+
+	err = manager.Init(ctx)
+	if err != nil {
+		return fmt.Errorf("container manager initialization failed: %w", err)
+	}
+
+	catalogDir := filepath.Dir(p.catalogPath)
+	for _, image := range images {
+		var options management.BuildOptions
+		options, err = catalog.GetBuildOptions(image, vars, catalogDir)
+		if err != nil {
+			return fmt.Errorf("cannot get build options of image '%s': %w", image.GetName(), err)
+		}
+
+		log.Printf("building image '%s' as %v\n", image.GetName(), options.Tags)
+		err = manager.BuildImage(ctx, options, os.Stderr)
+		if err != nil {
+			return err
+		}
+		log.Printf("image built '%s'\n", options.Tags[0])
+	}
+
+	return nil
+}
+
+func (p *BuildCmd) getImages(c *hub.Catalog) (images []*hub.Image, err error) {
+	if p.all {
+		return c.GetImages(), nil
+	}
+
+	for _, name := range p.imageNames.StringSlice {
+		image := catalog.FindImage(c, name)
+		if image == nil {
+			return nil, fmt.Errorf("image '%s' not found in catalog, available: %v", name, catalog.GetImageNames(c))
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+func (p *BuildCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	err := p.execute(ctx, f)
+	if err != nil {
+		log.Fatalf("got error: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
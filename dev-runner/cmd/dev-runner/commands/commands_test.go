@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/conainer/management/fake"
+)
+
+type testCommand interface {
+	SetFlags(f *flag.FlagSet)
+	execute(ctx context.Context, f *flag.FlagSet) (err error)
+}
+
+// setupFakeManager makes commands use the fake manager and keeps dev-runner state in a temp dir.
+func setupFakeManager(t *testing.T) *fake.Manager {
+	t.Helper()
+
+	manager := fake.NewFakeManager()
+	previous := createContainerManager
+	createContainerManager = func(_ string, _ management.ConnectionOptions) (management.ContainerManager, error) {
+		return manager, nil
+	}
+	t.Cleanup(func() { createContainerManager = previous })
+
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	return manager
+}
+
+// newTestDir creates dir inside a temp dir, so dev home dirs created next to it are cleaned up too.
+func newTestDir(t *testing.T, name string) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), name)
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		t.Fatalf("cannot create dir: %s", err)
+	}
+	return dir
+}
+
+func executeCommand(t *testing.T, cmd testCommand, args ...string) (err error) {
+	t.Helper()
+
+	f := flag.NewFlagSet("test", flag.ContinueOnError)
+	cmd.SetFlags(f)
+	err = f.Parse(args)
+	if err != nil {
+		t.Fatalf("cannot parse flags %v: %s", args, err)
+	}
+	return cmd.execute(context.Background(), f)
+}
+
+func findMountPoint(mountPoints []management.MountPoint, containerPath string) (mountPoint management.MountPoint, ok bool) {
+	for _, item := range mountPoints {
+		if item.ContainerPath == containerPath {
+			return item, true
+		}
+	}
+	return management.MountPoint{}, false
+}
+
+func findEnvironmentVariable(environmentVariables []management.EnvironmentVariable, name string) (value string, ok bool) {
+	for _, item := range environmentVariables {
+		if item.Name == name {
+			return item.Value, true
+		}
+	}
+	return "", false
+}
+
+func findLabel(labels []management.Label, name string) (value string, ok bool) {
+	for _, item := range labels {
+		if item.Name == name {
+			return item.Value, true
+		}
+	}
+	return "", false
+}
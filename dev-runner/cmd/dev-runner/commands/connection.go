@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+
+	"dev-runner/pkg/conainer/management"
+)
+
+// connectionFlags keeps connection flags until the container manager is known,
+// since 'docker-host' applies to docker only.
+type connectionFlags struct {
+	management.ConnectionOptions
+	dockerHost string
+}
+
+func setConnectionFlags(f *flag.FlagSet, connection *connectionFlags) {
+	f.StringVar(&connection.Name, "connection", "", "Named connection of containers manager: podman system connection or docker context. Default: discovered.")
+	f.StringVar(&connection.Url, "url", "", "URL of containers manager API, e.g. 'unix:///run/podman/podman.sock'. Overrides 'connection'.")
+	f.StringVar(&connection.dockerHost, "docker-host", "", "Docker host: 'unix://', 'tcp://' or 'ssh://' URL. Same as 'url', docker only.")
+}
+
+func (c connectionFlags) getConnectionOptions(containerManagerName string) (connection management.ConnectionOptions, err error) {
+	connection = c.ConnectionOptions
+	if c.dockerHost == "" {
+		return connection, nil
+	}
+	if containerManagerName != "docker" {
+		return management.ConnectionOptions{}, fmt.Errorf("'docker-host' must be used with docker containers manager only")
+	}
+	if connection.Url != "" && connection.Url != c.dockerHost {
+		return management.ConnectionOptions{}, fmt.Errorf("'docker-host' and 'url' must not be set to different values")
+	}
+	connection.Url = c.dockerHost
+	return connection, nil
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"flag"
+	"testing"
+
+	"dev-runner/pkg/conainer/management"
+)
+
+func TestConnectionFlags(t *testing.T) {
+	cases := []struct {
+		name                 string
+		args                 []string
+		containerManagerName string
+		expected             management.ConnectionOptions
+		expectError          bool
+	}{
+		{name: "url", args: []string{"-url", "unix:///a.sock"}, containerManagerName: "podman", expected: management.ConnectionOptions{Url: "unix:///a.sock"}},
+		{name: "docker host", args: []string{"-docker-host", "tcp://a:2375"}, containerManagerName: "docker", expected: management.ConnectionOptions{Url: "tcp://a:2375"}},
+		{name: "same url and docker host", args: []string{"-url", "tcp://a:2375", "-docker-host", "tcp://a:2375"}, containerManagerName: "docker", expected: management.ConnectionOptions{Url: "tcp://a:2375"}},
+		{name: "different url and docker host", args: []string{"-url", "tcp://a:2375", "-docker-host", "tcp://b:2375"}, containerManagerName: "docker", expectError: true},
+		{name: "docker host with podman", args: []string{"-docker-host", "tcp://a:2375"}, containerManagerName: "podman", expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var flags connectionFlags
+			f := flag.NewFlagSet("test", flag.ContinueOnError)
+			setConnectionFlags(f, &flags)
+			err := f.Parse(c.args)
+			if err != nil {
+				t.Fatalf("cannot parse flags: %s", err)
+			}
+
+			connection, err := flags.getConnectionOptions(c.containerManagerName)
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", connection)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot get connection options: %s", err)
+			}
+			if connection != c.expected {
+				t.Errorf("expected %+v, got %+v", c.expected, connection)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/google/subcommands"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"dev-runner/pkg/dev/devcontainer"
+	"dev-runner/pkg/dev/manifest/hub"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+// DevContainerCmd prints devcontainer.json translated into dev image manifest,
+// so the translation can be checked before 'run -devcontainer' or kept as manifest file.
+type DevContainerCmd struct {
+	devContainerPath string
+	hostWorkDirPath  string
+}
+
+func (*DevContainerCmd) Name() string {
+	return "devcontainer"
+}
+
+func (*DevContainerCmd) Synopsis() string {
+	return "devcontainer."
+}
+
+func (*DevContainerCmd) Usage() string {
+	return `
+`
+}
+
+func (p *DevContainerCmd) SetFlags(f *flag.FlagSet) {
+	workDir, _ := os.Getwd()
+
+	f.StringVar(&p.devContainerPath, "devcontainer", ".", "devcontainer.json file or directory to look it up in.")
+	f.StringVar(&p.hostWorkDirPath, "workDir", workDir, "Work dir on host the devcontainer variables are resolved against.")
+}
+
+func (p *DevContainerCmd) validateCliArguments() (err error) {
+	if !fp.IsExists(p.devContainerPath) {
+		return fmt.Errorf("'devcontainer' must be exists")
+	}
+	if !fp.IsDir(p.hostWorkDirPath) {
+		return fmt.Errorf("'workDir' must be exists and be directory")
+	}
+	return nil
+}
+
+func (p *DevContainerCmd) execute(_ context.Context, _ *flag.FlagSet) (err error) {
+	err = p.validateCliArguments()
+	if err != nil {
+		return fmt.Errorf("command line validation failed: %w", err)
+	}
+
+	var m *hub.Manifest
+	_, m, err = loadDevContainer(p.devContainerPath, p.hostWorkDirPath)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	data, err = prototext.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("cannot marshal manifest: %w", err)
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+func (p *DevContainerCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	err := p.execute(ctx, f)
+	if err != nil {
+		log.Fatalf("got error: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+// loadDevContainer reads devcontainer.json from path, which may be the directory to look it up in,
+// and translates it into manifest. Ignored settings are logged.
+func loadDevContainer(path string, hostWorkDir string) (config *devcontainer.Config, m *hub.Manifest, err error) {
+	if fp.IsDir(path) {
+		path, err = devcontainer.Find(path)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	config, err = devcontainer.LoadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hostWorkDir, err = filepath.Abs(hostWorkDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get absolute path of work dir: %w", err)
+	}
+
+	var unsupported []string
+	m, unsupported, err = devcontainer.ToManifest(config, hostWorkDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot translate devcontainer file '%s': %w", path, err)
+	}
+	for _, item := range unsupported {
+		log.Printf("devcontainer %s is not supported, ignored\n", item)
+	}
+	return config, m, nil
+}
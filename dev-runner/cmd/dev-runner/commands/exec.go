@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/google/subcommands"
+	"golang.org/x/term"
+
+	"dev-runner/pkg/cli"
+	"dev-runner/pkg/conainer/management"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+type ExecCmd struct {
+	containerManagerName string
+	connection           connectionFlags
+	imageTag             string
+	hostWorkDirPath      string
+	user                 string
+	workDir              string
+	environmentVariables cli.StringSlice
+	tty                  bool
+	interactive          bool
+}
+
+func (*ExecCmd) Name() string {
+	return "exec"
+}
+
+func (*ExecCmd) Synopsis() string {
+	return "exec."
+}
+
+func (*ExecCmd) Usage() string {
+	return `exec [flags] <command> [args...]
+`
+}
+
+func (p *ExecCmd) SetFlags(f *flag.FlagSet) {
+	workDir, _ := os.Getwd()
+	isTerminal := term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+
+	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
+	f.StringVar(&p.imageTag, "image", "", "Dev image tag.")
+	f.StringVar(&p.hostWorkDirPath, "workDir", workDir, "Work dir on host to mount inside container.")
+	f.StringVar(&p.user, "user", "user", "The container user username to run command as.")
+	f.StringVar(&p.workDir, "cwd", "/work", "The working dir inside container to run command in.")
+	f.Var(&p.environmentVariables, "env", "Environment variable 'NAME=VALUE' for command, can be set multiple times.")
+	f.BoolVar(&p.tty, "tty", isTerminal, "Allocate TTY for command. Default: true if stdin and stdout are terminals.")
+	f.BoolVar(&p.interactive, "interactive", true, "Forward stdin to command.")
+}
+
+func (p *ExecCmd) validateCliArguments(f *flag.FlagSet) (err error) {
+	if p.imageTag == "" {
+		return fmt.Errorf("'image' must be set with image tag")
+	}
+	if !fp.IsDir(p.hostWorkDirPath) {
+		return fmt.Errorf("'workDir' must be exists and be directory")
+	}
+	if f.NArg() == 0 {
+		return fmt.Errorf("command must be set")
+	}
+	for _, item := range p.environmentVariables.StringSlice {
+		if !strings.Contains(item, "=") {
+			return fmt.Errorf("'env' must be 'NAME=VALUE', got '%s'", item)
+		}
+	}
+	return nil
+}
+
+func (p *ExecCmd) execute(ctx context.Context, f *flag.FlagSet) (exitCode int, err error) {
+	err = p.validateCliArguments(f)
+	if err != nil {
+		return 0, fmt.Errorf("command line validation failed: %w", err)
+	}
+
+	var manager management.ContainerManager
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
+	if err != nil {
+		return 0, fmt.Errorf("cannot create container manager: %w", err)
+	}
+
+	err = manager.Init(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("container manager initialization failed: %w", err)
+	}
+
+	var containerName string
+	containerName, err = findContainerName(ctx, manager, p.imageTag, p.hostWorkDirPath)
+	if err != nil {
+		return 0, fmt.Errorf("cannot find container: %w", err)
+	}
+
+	var environmentVariables []management.EnvironmentVariable
+	for _, item := range p.environmentVariables.StringSlice {
+		name, value, _ := strings.Cut(item, "=")
+		environmentVariables = append(environmentVariables, management.EnvironmentVariable{Name: name, Value: value})
+	}
+
+	if p.tty && p.interactive {
+		var restore func()
+		restore, err = cli.MakeStdinRaw()
+		if err != nil {
+			return 0, err
+		}
+		defer restore()
+	}
+
+	exitCode, err = manager.ExecInContainer(
+		ctx,
+		containerName,
+		management.ExecOptions{
+			Cmd:                  f.Args(),
+			EnvironmentVariables: environmentVariables,
+			WorkDir:              p.workDir,
+			User:                 p.user,
+			Tty:                  p.tty,
+			AttachStdin:          p.interactive,
+		},
+		os.Stdin,
+		os.Stdout,
+		os.Stderr,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("exec in container failed: %w", err)
+	}
+
+	return exitCode, nil
+}
+
+func (p *ExecCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	exitCode, err := p.execute(ctx, f)
+	if err != nil {
+		log.Fatalf("got error: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitStatus(exitCode)
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/hooks"
+	"dev-runner/pkg/dev/manifest"
+
+	devlabels "dev-runner/pkg/dev/labels"
+)
+
+// defaultContainerUser runs hooks of containers created without user label.
+const defaultContainerUser = "user"
+
+func newHooksRunner(manager management.ContainerManager, containerName string, user string) hooks.Runner {
+	return hooks.Runner{
+		Manager:       manager,
+		ContainerName: containerName,
+		User:          user,
+		Stdout:        os.Stdout,
+		Stderr:        os.Stderr,
+	}
+}
+
+// runContainerHooks runs hooks of the phase from the manifest kept in container labels.
+// Hooks are executed inside the container, so they are skipped for container which is not running.
+func runContainerHooks(ctx context.Context, manager management.ContainerManager, containerName string, phase hooks.Phase) (err error) {
+	var containers []management.ContainerInfo
+	containers, err = manager.ListContainers(ctx)
+	if err != nil {
+		return fmt.Errorf("list containers failed: %w", err)
+	}
+
+	for _, info := range containers {
+		if info.Name != containerName {
+			continue
+		}
+		if !info.IsRunning() {
+			log.Printf("container '%s' is not running, %s hooks skipped\n", containerName, phase)
+			return nil
+		}
+
+		m, err := manifest.FindInLabels(info.Labels)
+		if err != nil {
+			return fmt.Errorf("cannot get container manifest: %w", err)
+		}
+		if m == nil {
+			return nil
+		}
+
+		user, ok := info.GetLabel(devlabels.User)
+		if !ok {
+			user = defaultContainerUser
+		}
+		return newHooksRunner(manager, containerName, user).Run(ctx, phase, hooks.GetHooks(m.GetSpec(), phase))
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/conainer/management/fake"
+	"dev-runner/pkg/dev/hooks"
+	"dev-runner/pkg/dev/manifest"
+	"dev-runner/pkg/dev/manifest/hub"
+	"dev-runner/pkg/dev/naming"
+)
+
+func addImageWithPreStopHook(t *testing.T, manager *fake.Manager, hook *hub.Hook) {
+	t.Helper()
+
+	m := &hub.Manifest{
+		Kind:    proto.String(manifest.Kind),
+		Version: proto.String(manifest.Version),
+		Spec: &hub.Spec{
+			Lifecycle: &hub.Lifecycle{PreStop: []*hub.Hook{hook}},
+		},
+	}
+	label, err := manifest.ToLabel(m)
+	if err != nil {
+		t.Fatalf("cannot encode manifest: %s", err)
+	}
+	manager.AddImage(testImageTag, []management.Label{label})
+}
+
+func TestStopCmdSkipsPreStopHooksOfStoppedContainer(t *testing.T) {
+	manager := setupFakeManager(t)
+	addImageWithPreStopHook(t, manager, &hub.Hook{Command: []string{"true"}})
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+	runTestContainer(t, workDir, homeDir)
+	containerName := naming.GenContainerName(testImageTag, workDir)
+
+	err := executeCommand(t, &StopCmd{}, "-image", testImageTag, "-workDir", workDir, "-keep")
+	if err != nil {
+		t.Fatalf("stop failed: %s", err)
+	}
+	if execCalls := manager.ExecCalls(); len(execCalls) != 1 {
+		t.Fatalf("expected 1 exec call for running container, got %+v", execCalls)
+	}
+
+	err = executeCommand(t, &StopCmd{}, "-image", testImageTag, "-workDir", workDir)
+	if err != nil {
+		t.Fatalf("stop of stopped container failed: %s", err)
+	}
+	if execCalls := manager.ExecCalls(); len(execCalls) != 1 {
+		t.Errorf("expected no exec calls for stopped container, got %+v", execCalls[1:])
+	}
+
+	exists, _ := manager.IsContainerExists(context.Background(), containerName)
+	if exists {
+		t.Errorf("container '%s' must be removed", containerName)
+	}
+}
+
+func TestRunContainerHooksTimeout(t *testing.T) {
+	manager := setupFakeManager(t)
+	addImageWithPreStopHook(t, manager, &hub.Hook{Command: []string{"sleep", "infinity"}, TimeoutSeconds: proto.Int32(1)})
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+	runTestContainer(t, workDir, homeDir)
+	containerName := naming.GenContainerName(testImageTag, workDir)
+
+	hung := make(chan struct{})
+	t.Cleanup(func() { close(hung) })
+	manager.SetExecHandler(func(management.ExecOptions, io.Reader, io.Writer, io.Writer) int {
+		<-hung
+		return 0
+	})
+
+	err := runContainerHooks(context.Background(), manager, containerName, hooks.PreStop)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "timed out after 1s") {
+		t.Errorf("expected hook to fail by timeout, got %v", err)
+	}
+}
+
+func TestRunContainerHooksCanceled(t *testing.T) {
+	manager := setupFakeManager(t)
+	addImageWithPreStopHook(t, manager, &hub.Hook{Command: []string{"sleep", "infinity"}})
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+	runTestContainer(t, workDir, homeDir)
+	containerName := naming.GenContainerName(testImageTag, workDir)
+
+	hung := make(chan struct{})
+	t.Cleanup(func() { close(hung) })
+	manager.SetExecHandler(func(management.ExecOptions, io.Reader, io.Writer, io.Writer) int {
+		<-hung
+		return 0
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := runContainerHooks(ctx, manager, containerName, hooks.PreStop)
+	if !errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected hook to fail by cancellation, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/google/subcommands"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/images"
+
+	devlabels "dev-runner/pkg/dev/labels"
+)
+
+type ImagesCmd struct {
+	containerManagerName string
+	connection           connectionFlags
+	format               string
+	filter               images.Filter
+}
+
+type imagesRow struct {
+	Tag         string   `json:"tag"`
+	Id          string   `json:"id"`
+	Ide         string   `json:"ide"`
+	Version     string   `json:"version"`
+	Compilers   []string `json:"compilers"`
+	Distro      string   `json:"distro"`
+	HasManifest bool     `json:"hasManifest"`
+	Created     string   `json:"created"`
+	Size        string   `json:"size"`
+}
+
+func (*ImagesCmd) Name() string {
+	return "images"
+}
+
+func (*ImagesCmd) Synopsis() string {
+	return "images."
+}
+
+func (*ImagesCmd) Usage() string {
+	return `
+`
+}
+
+func (p *ImagesCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
+	f.StringVar(&p.format, "format", formatTable, "Output format. Values: table or json.")
+	f.StringVar(&p.filter.Ide, "ide", "", "Show only images with the IDE, e.g. 'CLion'.")
+	f.StringVar(&p.filter.Version, "version", "", "Show only images with the IDE version.")
+	f.StringVar(&p.filter.Compiler, "compiler", "", "Show only images with the compiler, e.g. 'go'.")
+	f.StringVar(&p.filter.Distro, "distro", "", "Show only images with the distro, e.g. 'debian12' or 'debian12-slim'.")
+}
+
+func (p *ImagesCmd) validateCliArguments() (err error) {
+	if p.format != formatTable && p.format != formatJson {
+		return fmt.Errorf("'format' must be '%s' or '%s'", formatTable, formatJson)
+	}
+	return nil
+}
+
+func (p *ImagesCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
+	err = p.validateCliArguments()
+	if err != nil {
+		return fmt.Errorf("command line validation failed: %w", err)
+	}
+
+	var manager management.ContainerManager
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
+	if err != nil {
+		return fmt.Errorf("cannot create container manager: %w", err)
+	}
+
+	err = manager.Init(ctx)
+	if err != nil {
+		return fmt.Errorf("container manager initialization failed: %w", err)
+	}
+
+	var summaries []management.ImageSummary
+	summaries, err = manager.ListImages(ctx, devlabels.Ide)
+	if err != nil {
+		return fmt.Errorf("list images failed: %w", err)
+	}
+
+	var rows []imagesRow
+	for _, summary := range summaries {
+		info, ok := images.ParseImageInfo(summary)
+		if !ok || !p.filter.Match(info) {
+			continue
+		}
+		rows = append(rows, newImagesRows(info)...)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Tag < rows[j].Tag })
+
+	if p.format == formatJson {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TAG\tIDE\tVERSION\tCOMPILERS\tDISTRO\tMANIFEST\tCREATED\tSIZE")
+	for _, row := range rows {
+		compilers := "-"
+		if len(row.Compilers) > 0 {
+			compilers = strings.Join(row.Compilers, ",")
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%s\t%s\n",
+			row.Tag, row.Ide, orDash(row.Version), compilers, orDash(row.Distro), row.HasManifest, row.Created, row.Size)
+	}
+	return w.Flush()
+}
+
+func (p *ImagesCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	err := p.execute(ctx, f)
+	if err != nil {
+		log.Fatalf("got error: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+// newImagesRows returns row per image tag, so each of them can be copied to 'run -image'.
+func newImagesRows(info images.ImageInfo) (rows []imagesRow) {
+	row := imagesRow{
+		Tag:         "<none>",
+		Id:          info.Id,
+		Ide:         info.Ide,
+		Version:     info.Version,
+		Compilers:   info.Compilers,
+		Distro:      info.Distro(),
+		HasManifest: info.HasManifest,
+		Created:     "-",
+		Size:        units.HumanSize(float64(info.Size)),
+	}
+	if !info.Created.IsZero() && info.Created.Unix() != 0 {
+		row.Created = units.HumanDuration(time.Since(info.Created)) + " ago"
+	}
+
+	if len(info.Tags) == 0 {
+		return []imagesRow{row}
+	}
+	for _, tag := range info.Tags {
+		row.Tag = tag
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -3,13 +3,30 @@ package commands
 import (
 	"context"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"sort"
+	"strings"
 
 	"github.com/google/subcommands"
+
+	"dev-runner/pkg/archive"
+	"dev-runner/pkg/cli"
+	"dev-runner/pkg/conainer/management"
+
+	fp "dev-runner/pkg/filepath"
 )
 
+const devLabelsPrefix = "dev.containers."
+
 type LoadCmd struct {
 	containerManagerName string
+	connection           connectionFlags
+	filePath             string
+	checksumFilePath     string
+	verify               bool
 }
 
 func (*LoadCmd) Name() string {
@@ -27,15 +44,92 @@ func (*LoadCmd) Usage() string {
 
 func (p *LoadCmd) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
+	f.StringVar(&p.filePath, "file", "", "Image tarball to load. Compression(gzip, zstd, xz) is detected automatically.")
+	f.StringVar(&p.checksumFilePath, "sha256", "", "The sha256sum file to verify image tarball before loading. Default: '<file>.sha256' if 'verify' is set.")
+	f.BoolVar(&p.verify, "verify", false, "Verify image tarball with sha256 sidecar file before loading.")
+}
+
+func (p *LoadCmd) validateCliArguments() (err error) {
+	if p.filePath == "" {
+		return fmt.Errorf("'file' must be set with image tarball path")
+	}
+	if !fp.IsFile(p.filePath) {
+		return fmt.Errorf("'file' must be exists and be file")
+	}
+	if p.checksumFilePath != "" && !fp.IsFile(p.checksumFilePath) {
+		return fmt.Errorf("'sha256' must be exists and be file")
+	}
+	return nil
 }
 
 func (p *LoadCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
-	// TODO: wire code here
-	//var manager management.ContainerManager
-	//manager, err = creator.CreateContainerManager(p.containerManagerName)
-	//if err != nil {
-	//	return fmt.Errorf("cannot create container manager: %w", err)
-	//}
+	err = p.validateCliArguments()
+	if err != nil {
+		return fmt.Errorf("command line validation failed: %w", err)
+	}
+
+	checksumFilePath := p.checksumFilePath
+	if checksumFilePath == "" && p.verify {
+		checksumFilePath = p.filePath + ".sha256"
+	}
+	if checksumFilePath != "" {
+		log.Printf("verifying '%s' with '%s'\n", p.filePath, checksumFilePath)
+		err = archive.VerifySha256(p.filePath, checksumFilePath)
+		if err != nil {
+			return fmt.Errorf("image tarball verification failed: %w", err)
+		}
+	}
+
+	var manager management.ContainerManager
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
+	if err != nil {
+		return fmt.Errorf("cannot create container manager: %w", err)
+	}
+
+	err = manager.Init(ctx)
+	if err != nil {
+		return fmt.Errorf("container manager initialization failed: %w", err)
+	}
+
+	var f *os.File
+	f, err = os.Open(p.filePath)
+	if err != nil {
+		return fmt.Errorf("cannot open image tarball: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var stat os.FileInfo
+	stat, err = f.Stat()
+	if err != nil {
+		return fmt.Errorf("cannot stat image tarball: %w", err)
+	}
+
+	progress := cli.NewProgressReader(f, os.Stderr, stat.Size())
+
+	var r io.ReadCloser
+	var compression archive.Compression
+	r, compression, err = archive.NewDecompressReader(progress)
+	if err != nil {
+		return fmt.Errorf("cannot read image tarball: %w", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	log.Printf("loading '%s' (compression: %s)\n", p.filePath, compression)
+
+	var imageNames []string
+	imageNames, err = manager.LoadImage(ctx, r)
+	progress.Done()
+	if err != nil {
+		return fmt.Errorf("load image failed: %w", err)
+	}
+
+	for _, imageName := range imageNames {
+		err = printImageLabels(ctx, manager, imageName)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -48,3 +142,22 @@ func (p *LoadCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}
 	}
 	return subcommands.ExitSuccess
 }
+
+func printImageLabels(ctx context.Context, manager management.ContainerManager, imageName string) (err error) {
+	var labels []management.Label
+	labels, err = manager.GetImageLabels(ctx, imageName)
+	if err != nil {
+		return fmt.Errorf("cannot get labels of image '%s': %w", imageName, err)
+	}
+
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+
+	log.Printf("image loaded '%s'\n", imageName)
+	for _, label := range labels {
+		if !strings.HasPrefix(label.Name, devLabelsPrefix) {
+			continue
+		}
+		log.Printf("  %s=%s\n", label.Name, label.Value)
+	}
+	return nil
+}
@@ -2,14 +2,18 @@ package commands
 
 import (
 	"context"
-	"dev-runner/pkg/dev/naming"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 
 	"dev-runner/pkg/conainer/management"
-	"dev-runner/pkg/conainer/management/creator"
+	"dev-runner/pkg/dev/hooks"
 
 	"github.com/google/subcommands"
 
@@ -18,8 +22,15 @@ import (
 
 type LogsCmd struct {
 	containerManagerName string
+	connection           connectionFlags
 	imageTag             string
 	hostWorkDirPath      string
+	follow               bool
+	since                string
+	until                string
+	tail                 int
+	timestamps           bool
+	hooks                bool
 }
 
 func (*LogsCmd) Name() string {
@@ -39,8 +50,15 @@ func (p *LogsCmd) SetFlags(f *flag.FlagSet) {
 	workDir, _ := os.Getwd()
 
 	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
 	f.StringVar(&p.imageTag, "image", "", "Dev image tag.")
 	f.StringVar(&p.hostWorkDirPath, "workDir", workDir, "Work dir on host to mount inside container.")
+	f.BoolVar(&p.follow, "f", false, "Follow log output until interrupted.")
+	f.StringVar(&p.since, "since", "", "Show logs since timestamp(e.g. 2024-01-02T13:23:37Z) or relative(e.g. 42m).")
+	f.StringVar(&p.until, "until", "", "Show logs before timestamp(e.g. 2024-01-02T13:23:37Z) or relative(e.g. 42m).")
+	f.IntVar(&p.tail, "tail", -1, "Number of lines to show from the end of the logs, -1 shows all.")
+	f.BoolVar(&p.timestamps, "timestamps", true, "Show timestamps.")
+	f.BoolVar(&p.hooks, "hooks", false, "Show output of lifecycle hooks instead of container logs.")
 }
 
 func (p *LogsCmd) validateCliArguments() (err error) {
@@ -50,6 +68,9 @@ func (p *LogsCmd) validateCliArguments() (err error) {
 	if !fp.IsDir(p.hostWorkDirPath) {
 		return fmt.Errorf("'workDir' must be exists and be directory")
 	}
+	if p.tail < -1 {
+		return fmt.Errorf("'tail' must be -1 or positive number")
+	}
 	return nil
 }
 
@@ -60,7 +81,7 @@ func (p *LogsCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
 	}
 
 	var manager management.ContainerManager
-	manager, err = creator.CreateContainerManager(p.containerManagerName)
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
 	if err != nil {
 		return fmt.Errorf("cannot create container manager: %w", err)
 	}
@@ -70,9 +91,37 @@ func (p *LogsCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
 		return fmt.Errorf("container manager initialization failed: %w", err)
 	}
 
-	containerName := naming.GenContainerName(p.imageTag, p.hostWorkDirPath)
+	var containerName string
+	containerName, err = findContainerName(ctx, manager, p.imageTag, p.hostWorkDirPath)
+	if err != nil {
+		return fmt.Errorf("cannot find container: %w", err)
+	}
+
+	if p.hooks {
+		return printHooksLog(containerName)
+	}
+
+	tail := "all"
+	if p.tail >= 0 {
+		tail = strconv.Itoa(p.tail)
+	}
 
-	err = manager.PrintContainerLogs(ctx, containerName, os.Stdout)
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err = manager.PrintContainerLogs(
+		ctx,
+		containerName,
+		management.LogsOptions{
+			Follow:     p.follow,
+			Since:      p.since,
+			Until:      p.until,
+			Tail:       tail,
+			Timestamps: p.timestamps,
+		},
+		os.Stdout,
+		os.Stderr,
+	)
 	if err != nil {
 		return fmt.Errorf("print container logs failed: %w", err)
 	}
@@ -88,3 +137,27 @@ func (p *LogsCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}
 	}
 	return subcommands.ExitSuccess
 }
+
+func printHooksLog(containerName string) (err error) {
+	var logPath string
+	logPath, err = hooks.LogPath(containerName)
+	if err != nil {
+		return err
+	}
+
+	var logFile *os.File
+	logFile, err = os.Open(logPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot open hooks log: %w", err)
+	}
+	defer func() { _ = logFile.Close() }()
+
+	_, err = io.Copy(os.Stdout, logFile)
+	if err != nil {
+		return fmt.Errorf("cannot print hooks log: %w", err)
+	}
+	return nil
+}
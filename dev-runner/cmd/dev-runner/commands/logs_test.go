@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"testing"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/conainer/management/fake"
+	"dev-runner/pkg/dev/naming"
+)
+
+func TestLogsCmdPassesOptions(t *testing.T) {
+	manager := setupFakeManager(t)
+	manager.AddImage(testImageTag, nil)
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+	runTestContainer(t, workDir, homeDir)
+	containerName := naming.GenContainerName(testImageTag, workDir)
+
+	err := executeCommand(t, &LogsCmd{},
+		"-image", testImageTag,
+		"-workDir", workDir,
+		"-since", "42m",
+		"-tail", "10",
+		"-timestamps=false",
+	)
+	if err != nil {
+		t.Fatalf("logs failed: %s", err)
+	}
+
+	err = executeCommand(t, &LogsCmd{}, "-image", testImageTag, "-workDir", workDir)
+	if err != nil {
+		t.Fatalf("logs failed: %s", err)
+	}
+
+	expectedCalls := []fake.PrintContainerLogsCall{
+		{
+			ContainerName: containerName,
+			Options:       management.LogsOptions{Since: "42m", Tail: "10"},
+		},
+		{
+			ContainerName: containerName,
+			Options:       management.LogsOptions{Tail: "all", Timestamps: true},
+		},
+	}
+	calls := manager.PrintContainerLogsCalls()
+	if len(calls) != len(expectedCalls) {
+		t.Fatalf("expected %d PrintContainerLogs calls, got %+v", len(expectedCalls), calls)
+	}
+	for i, expected := range expectedCalls {
+		if calls[i] != expected {
+			t.Errorf("PrintContainerLogs call #%d: expected %+v, got %+v", i+1, expected, calls[i])
+		}
+	}
+}
+
+func TestLogsCmdValidatesTail(t *testing.T) {
+	manager := setupFakeManager(t)
+	workDir := newTestDir(t, "project")
+
+	err := executeCommand(t, &LogsCmd{}, "-image", testImageTag, "-workDir", workDir, "-tail", "-2")
+	if err == nil {
+		t.Fatalf("logs must fail on invalid tail")
+	}
+	if calls := manager.PrintContainerLogsCalls(); len(calls) != 0 {
+		t.Errorf("expected no PrintContainerLogs calls, got %+v", calls)
+	}
+}
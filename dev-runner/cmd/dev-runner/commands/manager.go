@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/conainer/management/creator"
+)
+
+// createContainerManager is replaced by tests to run commands against the fake manager.
+var createContainerManager = creator.CreateContainerManager
+
+func newContainerManager(name string, flags connectionFlags) (manager management.ContainerManager, err error) {
+	var connection management.ConnectionOptions
+	connection, err = flags.getConnectionOptions(name)
+	if err != nil {
+		return nil, err
+	}
+	return createContainerManager(name, connection)
+}
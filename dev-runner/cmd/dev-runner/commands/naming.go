@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/naming"
+	"dev-runner/pkg/dev/ports"
+	"dev-runner/pkg/dev/state"
+	"dev-runner/pkg/ssh"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+// findContainerName returns the name of existing dev container for the image and work dir.
+// Containers created by the previous naming scheme are still found, so they can be stopped
+// and recreated with the new name.
+func findContainerName(
+	ctx context.Context,
+	manager management.ContainerManager,
+	imageTag string,
+	hostWorkDir string,
+) (containerName string, err error) {
+	containerName = naming.GenContainerName(imageTag, hostWorkDir)
+
+	var exists bool
+	exists, err = manager.IsContainerExists(ctx, containerName)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return containerName, nil
+	}
+
+	legacyContainerName := naming.GenLegacyContainerName(imageTag, hostWorkDir)
+	exists, err = manager.IsContainerExists(ctx, legacyContainerName)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		log.Printf("container '%s' uses legacy name, recreate it with 'stop' and 'run' to migrate\n", legacyContainerName)
+		return legacyContainerName, nil
+	}
+
+	return containerName, nil
+}
+
+// getDevHomeDir returns dev home dir next to the resolved work dir, so it is the same
+// whatever path is used to reach the work dir.
+func getDevHomeDir(imageTag string, hostWorkDir string) string {
+	workDir := naming.ResolveWorkDir(hostWorkDir)
+	return filepath.Join(filepath.Dir(workDir), naming.GenDevHomeDirName(imageTag, workDir))
+}
+
+// migrateDevHomeDir renames dev home dir created by the previous naming scheme.
+func migrateDevHomeDir(imageTag string, hostWorkDir string) (err error) {
+	workDir := naming.ResolveWorkDir(hostWorkDir)
+	devHomeDir := getDevHomeDir(imageTag, workDir)
+	legacyDevHomeDir := filepath.Join(filepath.Dir(workDir), naming.GenLegacyDevHomeDirName(imageTag, workDir))
+
+	if fp.IsExists(devHomeDir) || !fp.IsDir(legacyDevHomeDir) {
+		return nil
+	}
+
+	err = os.Rename(legacyDevHomeDir, devHomeDir)
+	if err != nil {
+		return fmt.Errorf("cannot rename dev home dir '%s' to '%s': %w", legacyDevHomeDir, devHomeDir, err)
+	}
+	log.Printf("dev home dir '%s' migrated to '%s'\n", legacyDevHomeDir, devHomeDir)
+	return nil
+}
+
+func getContainerKeyPair(containerName string) (keyPair ssh.KeyPair, err error) {
+	var containerStateDir string
+	containerStateDir, err = state.ContainerDir(containerName)
+	if err != nil {
+		return ssh.KeyPair{}, err
+	}
+
+	keyPair, err = ssh.GetKeyPair(containerStateDir)
+	if err != nil {
+		return ssh.KeyPair{}, fmt.Errorf("cannot get SSH key pair of container '%s': %w", containerName, err)
+	}
+	return keyPair, nil
+}
+
+func loadKnownHosts() (knownHosts *ssh.KnownHosts, err error) {
+	var path string
+	path, err = state.KnownHostsPath()
+	if err != nil {
+		return nil, err
+	}
+	return ssh.LoadKnownHosts(path)
+}
+
+// forgetHostKey drops pinned host key of the container which is removed or recreated.
+func forgetHostKey(containerName string) (err error) {
+	var path string
+	path, err = state.KnownHostsPath()
+	if err != nil {
+		return err
+	}
+	return ssh.UpdateKnownHosts(path, func(knownHosts *ssh.KnownHosts) error {
+		knownHosts.Forget(containerName)
+		return nil
+	})
+}
+
+// forgetContainer drops host key and SSH port registered for the removed container.
+func forgetContainer(containerName string) (err error) {
+	err = forgetHostKey(containerName)
+	if err != nil {
+		return fmt.Errorf("cannot forget container host key: %w", err)
+	}
+
+	err = ports.UpdateDefaultRegistry(func(registry *ports.Registry) error {
+		registry.Delete(containerName)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot forget container SSH port: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/naming"
+
+	devlabels "dev-runner/pkg/dev/labels"
+)
+
+func TestGetDevHomeDirResolvesWorkDir(t *testing.T) {
+	workDir := newTestDir(t, "project")
+	link := filepath.Join(t.TempDir(), "link")
+	err := os.Symlink(workDir, link)
+	if err != nil {
+		t.Fatalf("cannot create work dir link: %s", err)
+	}
+
+	expected := getDevHomeDir(testImageTag, workDir)
+	if filepath.Dir(expected) != filepath.Dir(naming.ResolveWorkDir(workDir)) {
+		t.Errorf("dev home dir '%s' must be next to the work dir", expected)
+	}
+	if actual := getDevHomeDir(testImageTag, link); actual != expected {
+		t.Errorf("dev home dir through link: expected '%s', got '%s'", expected, actual)
+	}
+}
+
+func TestLookupSshPortOfLegacyContainer(t *testing.T) {
+	manager := setupFakeManager(t)
+	manager.AddImage(testImageTag, nil)
+	workDir := newTestDir(t, "project")
+
+	legacyContainerName := naming.GenLegacyContainerName(testImageTag, workDir)
+	_, err := manager.RunContainer(
+		context.Background(),
+		testImageTag,
+		legacyContainerName,
+		nil,
+		nil,
+		nil,
+		[]management.Label{{Name: devlabels.SshPort, Value: "2233"}},
+		management.NetworkHost,
+		management.UserMapping{},
+		management.ResourceLimits{},
+		management.SecurityOptions{},
+		false,
+	)
+	if err != nil {
+		t.Fatalf("cannot run legacy container: %s", err)
+	}
+
+	containerName, err := findContainerName(context.Background(), manager, testImageTag, workDir)
+	if err != nil {
+		t.Fatalf("cannot find container: %s", err)
+	}
+	if containerName != legacyContainerName {
+		t.Fatalf("expected legacy container '%s', got '%s'", legacyContainerName, containerName)
+	}
+
+	port, err := lookupSshPort(context.Background(), manager, containerName)
+	if err != nil {
+		t.Fatalf("cannot look up SSH port: %s", err)
+	}
+	if port != 2233 {
+		t.Errorf("expected SSH port from container label 2233, got %d", port)
+	}
+}
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/subcommands"
+
+	"dev-runner/pkg/conainer/management"
+
+	devlabels "dev-runner/pkg/dev/labels"
+)
+
+const (
+	formatTable = "table"
+	formatJson  = "json"
+)
+
+type PsCmd struct {
+	containerManagerName string
+	connection           connectionFlags
+	format               string
+}
+
+type psRow struct {
+	Name    string `json:"name"`
+	Image   string `json:"image"`
+	WorkDir string `json:"workDir"`
+	SshPort string `json:"sshPort"`
+	Uptime  string `json:"uptime"`
+	State   string `json:"state"`
+}
+
+func (*PsCmd) Name() string {
+	return "ps"
+}
+
+func (*PsCmd) Synopsis() string {
+	return "ps."
+}
+
+func (*PsCmd) Usage() string {
+	return `
+`
+}
+
+func (p *PsCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
+	f.StringVar(&p.format, "format", formatTable, "Output format. Values: table or json.")
+}
+
+func (p *PsCmd) validateCliArguments() (err error) {
+	if p.format != formatTable && p.format != formatJson {
+		return fmt.Errorf("'format' must be '%s' or '%s'", formatTable, formatJson)
+	}
+	return nil
+}
+
+func (p *PsCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
+	err = p.validateCliArguments()
+	if err != nil {
+		return fmt.Errorf("command line validation failed: %w", err)
+	}
+
+	var manager management.ContainerManager
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
+	if err != nil {
+		return fmt.Errorf("cannot create container manager: %w", err)
+	}
+
+	err = manager.Init(ctx)
+	if err != nil {
+		return fmt.Errorf("container manager initialization failed: %w", err)
+	}
+
+	var containers []management.ContainerInfo
+	containers, err = manager.ListContainers(ctx)
+	if err != nil {
+		return fmt.Errorf("list containers failed: %w", err)
+	}
+
+	rows := make([]psRow, 0, len(containers))
+	for _, item := range containers {
+		rows = append(rows, newPsRow(item))
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+
+	if p.format == formatJson {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tIMAGE\tWORKDIR\tSSH PORT\tUPTIME\tSTATE")
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", row.Name, row.Image, row.WorkDir, row.SshPort, row.Uptime, row.State)
+	}
+	return w.Flush()
+}
+
+func (p *PsCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	err := p.execute(ctx, f)
+	if err != nil {
+		log.Fatalf("got error: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+func newPsRow(info management.ContainerInfo) psRow {
+	row := psRow{
+		Name:    info.Name,
+		Image:   info.Image,
+		WorkDir: "-",
+		SshPort: "-",
+		Uptime:  "-",
+		State:   info.State,
+	}
+	if value, ok := info.GetLabel(devlabels.ImageTag); ok {
+		row.Image = value
+	}
+	if value, ok := info.GetLabel(devlabels.WorkDir); ok {
+		row.WorkDir = value
+	}
+	if value, ok := info.GetLabel(devlabels.SshPort); ok {
+		row.SshPort = value
+	}
+	if !info.StartedAt.IsZero() {
+		row.Uptime = time.Since(info.StartedAt).Truncate(time.Second).String()
+	}
+	return row
+}
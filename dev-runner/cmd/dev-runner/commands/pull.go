@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/conainer/registry"
+)
+
+type pullPolicy string
+
+const (
+	pullMissing pullPolicy = "missing"
+	pullAlways  pullPolicy = "always"
+	pullNever   pullPolicy = "never"
+)
+
+func getPullPolicies() []pullPolicy {
+	return []pullPolicy{pullMissing, pullAlways, pullNever}
+}
+
+func isPullPolicy(value string) bool {
+	return slices.Contains(getPullPolicies(), pullPolicy(value))
+}
+
+// ensureImage pulls image according to the policy, credentials are taken from docker config.
+func ensureImage(ctx context.Context, manager management.ContainerManager, imageName string, policy pullPolicy) (err error) {
+	if policy != pullAlways {
+		var exists bool
+		exists, err = manager.IsImageExists(ctx, imageName)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+		if policy == pullNever {
+			return fmt.Errorf("image '%s' not found and pull policy is '%s'", imageName, policy)
+		}
+	}
+
+	var auth management.RegistryAuth
+	auth, err = registry.GetAuth(imageName)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("pulling image '%s'\n", imageName)
+	err = manager.PullImage(ctx, imageName, auth, os.Stderr)
+	if err != nil {
+		return err
+	}
+	return nil
+}
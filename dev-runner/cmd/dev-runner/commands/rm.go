@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"dev-runner/pkg/conainer/management"
+
+	"github.com/google/subcommands"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+type RmCmd struct {
+	containerManagerName string
+	connection           connectionFlags
+	imageTag             string
+	hostWorkDirPath      string
+	force                bool
+}
+
+func (*RmCmd) Name() string {
+	return "rm"
+}
+
+func (*RmCmd) Synopsis() string {
+	return "rm."
+}
+
+func (*RmCmd) Usage() string {
+	return `
+`
+}
+
+func (p *RmCmd) SetFlags(f *flag.FlagSet) {
+	workDir, _ := os.Getwd()
+
+	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
+	f.StringVar(&p.imageTag, "image", "", "Dev image tag.")
+	f.StringVar(&p.hostWorkDirPath, "workDir", workDir, "Work dir on host to mount inside container.")
+	f.BoolVar(&p.force, "force", false, "Kill and remove running container.")
+}
+
+func (p *RmCmd) validateCliArguments() (err error) {
+	if p.imageTag == "" {
+		return fmt.Errorf("'image' must be set with image tag")
+	}
+	if !fp.IsDir(p.hostWorkDirPath) {
+		return fmt.Errorf("'workDir' must be exists and be directory")
+	}
+	return nil
+}
+
+func (p *RmCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
+	err = p.validateCliArguments()
+	if err != nil {
+		return fmt.Errorf("command line validation failed: %w", err)
+	}
+
+	var manager management.ContainerManager
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
+	if err != nil {
+		return fmt.Errorf("cannot create container manager: %w", err)
+	}
+
+	err = manager.Init(ctx)
+	if err != nil {
+		return fmt.Errorf("container manager initialization failed: %w", err)
+	}
+
+	var containerName string
+	containerName, err = findContainerName(ctx, manager, p.imageTag, p.hostWorkDirPath)
+	if err != nil {
+		return fmt.Errorf("cannot find container: %w", err)
+	}
+
+	err = manager.RemoveContainer(ctx, containerName, p.force)
+	if err != nil {
+		return fmt.Errorf("remove container failed: %w", err)
+	}
+
+	err = forgetContainer(containerName)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *RmCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	err := p.execute(ctx, f)
+	if err != nil {
+		log.Fatalf("got error: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
@@ -6,21 +6,50 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 
-	"dev-runner/pkg/conainer/management/creator"
+	"dev-runner/pkg/cli"
+	"dev-runner/pkg/dev/devcontainer"
+	"dev-runner/pkg/dev/hooks"
+	"dev-runner/pkg/dev/manifest"
+	"dev-runner/pkg/dev/manifest/hub"
+	"dev-runner/pkg/dev/ports"
+	"dev-runner/pkg/ssh"
 
 	"dev-runner/pkg/conainer/management"
 	"github.com/google/subcommands"
 
+	devlabels "dev-runner/pkg/dev/labels"
 	fp "dev-runner/pkg/filepath"
 )
 
+// Environment variables to configure sshd inside dev images.
+const (
+	sshPortEnvironmentVariable                   = "DEV_CONTAINER_SSH_PORT"
+	sshPasswordAuthenticationEnvironmentVariable = "DEV_CONTAINER_SSH_PASSWORD_AUTHENTICATION"
+)
+
+// defaultShmSize is used when neither manifest nor flags set shm size,
+// the runtime default of 64MB is too small for IDEs and Chromium based tools.
+const defaultShmSize = 1 << 30
+
+// Dev images create the user with uid 1000 in the root group.
+const (
+	imageUserUid = 1000
+	imageUserGid = 0
+)
+
 type RunCmd struct {
 	containerManagerName string
+	connection           connectionFlags
 	imageTag             string
+	manifestPath         string
+	devContainerPath     string
 	hostWorkDirPath      string
 	hostHomeDir          string
 	user                 string
@@ -28,6 +57,19 @@ type RunCmd struct {
 	containerSshPort     int
 	networkMode          string
 	interactive          bool
+	sshKey               bool
+	mapUser              bool
+	cpus                 float64
+	memory               string
+	memorySwap           string
+	pidsLimit            int64
+	shmSize              string
+	ulimits              cli.StringSlice
+	securityProfile      string
+	capAdd               cli.StringSlice
+	capDrop              cli.StringSlice
+	seccompProfile       string
+	pullPolicy           string
 	sort.StringSlice
 }
 
@@ -49,37 +91,85 @@ func (p *RunCmd) SetFlags(f *flag.FlagSet) {
 	homeDir, _ := os.UserHomeDir()
 
 	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
 	f.StringVar(&p.imageTag, "image", "", "Dev image tag.")
+	f.StringVar(&p.manifestPath, "manifest", "", "Dev image manifest textproto file. By default the manifest is taken from the image label 'dev.containers.manifest'.")
+	f.StringVar(&p.devContainerPath, "devcontainer", "", "devcontainer.json file or directory to look it up in, e.g. '.'. Its settings are merged over the manifest, its image and remoteUser are used unless 'image' and 'user' are set.")
 	f.StringVar(&p.hostWorkDirPath, "workDir", workDir, "Work dir on host to mount inside container.")
 	f.StringVar(&p.hostHomeDir, "homeDir", homeDir, "Home dir on host to mount directories(.ssh, .docker and etc) inside container.")
 	f.StringVar(&p.user, "user", "user", "The container user username.")
 	f.StringVar(&p.host, "host", "localhost", "The host to bind containers ports to.")
-	f.IntVar(&p.containerSshPort, "containerSshPort", 2221, "The SSH port to bind from container.")
+	f.IntVar(&p.containerSshPort, "containerSshPort", 0, "The SSH port to bind from container. Default: free port registered for the container.")
 	f.StringVar(&p.networkMode, "network", "host", "The network mode for container.")
-	f.BoolVar(&p.interactive, "interactive", false, "Run container in interactive mode to debug.")
+	f.BoolVar(&p.interactive, "interactive", false, "Run container in interactive mode to debug, post-create and post-start hooks are skipped.")
+	f.BoolVar(&p.mapUser, "mapUser", true, "Map the container user to the host user, so files in work dir keep host ownership.")
+	f.Float64Var(&p.cpus, "cpus", 0, "Number of CPUs the container can use. Default: manifest value or unlimited.")
+	f.StringVar(&p.memory, "memory", "", "Memory limit, e.g. '8g'. Default: manifest value or unlimited.")
+	f.StringVar(&p.memorySwap, "memorySwap", "", "Memory plus swap limit, e.g. '16g', '-1' for unlimited swap. Default: manifest value or runtime default.")
+	f.Int64Var(&p.pidsLimit, "pidsLimit", 0, "Limit of processes in the container. Default: manifest value or unlimited.")
+	f.StringVar(&p.shmSize, "shmSize", "", "Size of /dev/shm, e.g. '2g'. Default: manifest value or '1g'.")
+	f.Var(&p.ulimits, "ulimit", "Ulimit in form 'name=soft[:hard]', e.g. 'nofile=1024:65536'. Can be repeated.")
+	f.StringVar(&p.pullPolicy, "pull", string(pullMissing), fmt.Sprintf("Pull image policy. Values: %v.", getPullPolicies()))
+	f.StringVar(&p.securityProfile, "security", "", fmt.Sprintf("Security profile. Values: %v. Default: manifest value or 'debug'.", management.GetSecurityProfiles()))
+	f.Var(&p.capAdd, "capAdd", "Capability to add on top of security profile. Can be repeated.")
+	f.Var(&p.capDrop, "capDrop", "Capability to drop on top of security profile. Can be repeated.")
+	f.StringVar(&p.seccompProfile, "seccompProfile", "", "Path to seccomp JSON profile or 'unconfined'. Default: manifest or security profile value.")
+	f.BoolVar(&p.sshKey, "sshKey", true, "Generate SSH key for container and inject it as authorized key, SSH password authentication is disabled then.")
 }
 
 func (p *RunCmd) validateCliArguments() (err error) {
-	if p.imageTag == "" {
+	if p.imageTag == "" && p.devContainerPath == "" {
 		return fmt.Errorf("'image' must be set with image tag")
 	}
+	if p.devContainerPath != "" && !fp.IsExists(p.devContainerPath) {
+		return fmt.Errorf("'devcontainer' must be exists")
+	}
 	if !fp.IsDir(p.hostWorkDirPath) {
 		return fmt.Errorf("'workDir' must be exists and be directory")
 	}
 	if !fp.IsDir(p.hostHomeDir) {
 		return fmt.Errorf("'homeDir' must be exists and be directory")
 	}
+	if p.containerSshPort < 0 || p.containerSshPort > 65535 {
+		return fmt.Errorf("'containerSshPort' must be valid port number")
+	}
+	if p.manifestPath != "" && !fp.IsFile(p.manifestPath) {
+		return fmt.Errorf("'manifest' must be exists and be file")
+	}
+	if !isPullPolicy(p.pullPolicy) {
+		return fmt.Errorf("'pull' must be one of %v", getPullPolicies())
+	}
+	if p.cpus < 0 {
+		return fmt.Errorf("'cpus' must be positive")
+	}
+	if p.pidsLimit < 0 {
+		return fmt.Errorf("'pidsLimit' must be positive")
+	}
+	if p.securityProfile != "" && !slices.Contains(management.GetSecurityProfiles(), management.SecurityProfile(p.securityProfile)) {
+		return fmt.Errorf("'security' must be one of %v", management.GetSecurityProfiles())
+	}
+	if p.seccompProfile != "" && p.seccompProfile != management.SeccompUnconfined && !fp.IsFile(p.seccompProfile) {
+		return fmt.Errorf("'seccompProfile' must be exists and be file")
+	}
 	return nil
 }
 
-func (p *RunCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
+func (p *RunCmd) execute(ctx context.Context, f *flag.FlagSet) (err error) {
 	err = p.validateCliArguments()
 	if err != nil {
 		return fmt.Errorf("command line validation failed: %w", err)
 	}
 
+	var devContainerManifest *hub.Manifest
+	if p.devContainerPath != "" {
+		devContainerManifest, err = p.applyDevContainer(f)
+		if err != nil {
+			return err
+		}
+	}
+
 	var manager management.ContainerManager
-	manager, err = creator.CreateContainerManager(p.containerManagerName)
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
 	if err != nil {
 		return fmt.Errorf("cannot create container manager: %w", err)
 	}
@@ -91,20 +181,150 @@ func (p *RunCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
 
 	containerName := naming.GenContainerName(p.imageTag, p.hostWorkDirPath)
 
+	// The container of the previous naming scheme is not replaced, so it is reported to be stopped.
+	var existingContainerName string
+	existingContainerName, err = findContainerName(ctx, manager, p.imageTag, p.hostWorkDirPath)
+	if err != nil {
+		return fmt.Errorf("cannot find container: %w", err)
+	}
+	if existingContainerName != containerName {
+		log.Printf("container '%s' is created next to legacy container '%s', stop it to free its resources\n",
+			containerName, existingContainerName)
+	}
+
+	err = migrateDevHomeDir(p.imageTag, p.hostWorkDirPath)
+	if err != nil {
+		return err
+	}
+
 	var networkMode management.NetworkMode
 	networkMode, err = getNetworkMode(p.networkMode)
 	if err != nil {
 		return err
 	}
 
-	var mountPoints []management.MountPoint
-	mountPoints, err = getMountPoints(p.imageTag, p.hostWorkDirPath, p.hostHomeDir, p.user)
+	err = ensureImage(ctx, manager, p.imageTag, pullPolicy(p.pullPolicy))
 	if err != nil {
-		return fmt.Errorf("cannot get mount points: %w", err)
+		return fmt.Errorf("cannot get image: %w", err)
 	}
 
+	var m *hub.Manifest
+	m, err = p.getManifest(ctx, manager, devContainerManifest)
+	if err != nil {
+		return err
+	}
+
+	var mountPoints []management.MountPoint
 	var environmentVariables []management.EnvironmentVariable
 	var portBindings []management.PortBinding
+	if m != nil {
+		mountPoints, environmentVariables, portBindings, err = getManifestSpec(
+			m,
+			p.imageTag,
+			p.hostWorkDirPath,
+			p.hostHomeDir,
+			p.user,
+		)
+		if err != nil {
+			return fmt.Errorf("cannot apply manifest: %w", err)
+		}
+	} else {
+		mountPoints, err = getMountPoints(p.imageTag, p.hostWorkDirPath, p.hostHomeDir, p.user)
+		if err != nil {
+			return fmt.Errorf("cannot get mount points: %w", err)
+		}
+	}
+
+	var resourceLimits management.ResourceLimits
+	resourceLimits, err = p.getResourceLimits(m)
+	if err != nil {
+		return err
+	}
+
+	var securityOptions management.SecurityOptions
+	securityOptions, err = p.getSecurityOptions(m)
+	if err != nil {
+		return err
+	}
+
+	var sshPort int
+	var releaseSshPort func() error
+	sshPort, releaseSshPort, err = reserveSshPort(containerName, p.containerSshPort)
+	if err != nil {
+		return err
+	}
+
+	environmentVariables = append(
+		environmentVariables,
+		management.EnvironmentVariable{
+			Name:  sshPortEnvironmentVariable,
+			Value: strconv.Itoa(sshPort),
+		},
+	)
+
+	if p.sshKey {
+		var keyMountPoint management.MountPoint
+		keyMountPoint, err = getAuthorizedKeysMountPoint(containerName, p.user)
+		if err != nil {
+			return err
+		}
+		mountPoints = append(mountPoints, keyMountPoint)
+		environmentVariables = append(
+			environmentVariables,
+			management.EnvironmentVariable{
+				Name:  sshPasswordAuthenticationEnvironmentVariable,
+				Value: "no",
+			},
+		)
+	}
+
+	if networkMode == management.NetworkBridge {
+		var hostAddress string
+		hostAddress, err = resolveHostAddress(p.host)
+		if err != nil {
+			return err
+		}
+		portBindings = append(
+			portBindings,
+			management.PortBinding{
+				ContainerPort: sshPort,
+				HostPort:      sshPort,
+				HostAddress:   hostAddress,
+			},
+		)
+	}
+
+	labels := []management.Label{
+		{Name: devlabels.ImageTag, Value: p.imageTag},
+		{Name: devlabels.WorkDir, Value: naming.ResolveWorkDir(p.hostWorkDirPath)},
+		{Name: devlabels.SshPort, Value: strconv.Itoa(sshPort)},
+		{Name: devlabels.User, Value: p.user},
+	}
+
+	// Manifest is kept in container label for lifecycle hooks run by 'start' and 'stop'.
+	if m != nil {
+		var manifestLabel management.Label
+		manifestLabel, err = manifest.ToLabel(m)
+		if err != nil {
+			return err
+		}
+		labels = append(labels, manifestLabel)
+	}
+
+	if p.interactive {
+		// The command returns only when the interactive container exits, so there is nothing to run hooks in.
+		if m != nil && (len(hooks.GetHooks(m.GetSpec(), hooks.PostCreate)) != 0 ||
+			len(hooks.GetHooks(m.GetSpec(), hooks.PostStart)) != 0) {
+			log.Printf("skipping %s and %s hooks in interactive mode\n", hooks.PostCreate, hooks.PostStart)
+		}
+
+		var restore func()
+		restore, err = cli.MakeStdinRaw()
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
 
 	var containerId string
 	containerId, err = manager.RunContainer(
@@ -114,16 +334,249 @@ func (p *RunCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
 		mountPoints,
 		environmentVariables,
 		portBindings,
+		labels,
 		networkMode,
+		p.getUserMapping(),
+		resourceLimits,
+		securityOptions,
+		p.interactive,
 	)
 	if err != nil {
+		releaseErr := releaseSshPort()
+		if releaseErr != nil {
+			log.Printf("cannot release SSH port %d: %s\n", sshPort, releaseErr.Error())
+		}
 		return fmt.Errorf("start container failed: %w", err)
 	}
 
+	// The container is new, so its host key must be pinned again on next attach.
+	err = forgetHostKey(containerName)
+	if err != nil {
+		return fmt.Errorf("cannot forget container host key: %w", err)
+	}
+
+	if p.interactive {
+		log.Printf("container exited '%s'\n", containerId)
+		return nil
+	}
+
 	log.Printf("container started '%s'\n", containerId)
+
+	if m != nil {
+		runner := newHooksRunner(manager, containerName, p.user)
+		err = runner.Run(ctx, hooks.PostCreate, hooks.GetHooks(m.GetSpec(), hooks.PostCreate))
+		if err != nil {
+			return err
+		}
+		err = runner.Run(ctx, hooks.PostStart, hooks.GetHooks(m.GetSpec(), hooks.PostStart))
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// reserveSshPort registers the SSH port for the container before it is created, so concurrent
+// runs do not take the same port. Port 0 allocates free one. Release restores the previous registration.
+func reserveSshPort(containerName string, port int) (reserved int, release func() error, err error) {
+	var previous int
+	var registered bool
+	err = ports.UpdateDefaultRegistry(func(registry *ports.Registry) (err error) {
+		previous, registered = registry.Lookup(containerName)
+		if port != 0 {
+			registry.Set(containerName, port)
+			reserved = port
+			return nil
+		}
+		reserved, err = registry.Allocate(containerName)
+		if err != nil {
+			return fmt.Errorf("cannot allocate SSH port: %w", err)
+		}
+		log.Printf("using SSH port %d\n", reserved)
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	release = func() error {
+		return ports.UpdateDefaultRegistry(func(registry *ports.Registry) error {
+			if registered {
+				registry.Set(containerName, previous)
+			} else {
+				registry.Delete(containerName)
+			}
+			return nil
+		})
+	}
+	return reserved, release, nil
+}
+
+func (p *RunCmd) getUserMapping() management.UserMapping {
+	if !p.mapUser {
+		return management.UserMapping{}
+	}
+	return management.UserMapping{
+		Enabled:      true,
+		HostUid:      os.Getuid(),
+		HostGid:      os.Getgid(),
+		ContainerUid: imageUserUid,
+		ContainerGid: imageUserGid,
+	}
+}
+
+// getResourceLimits takes limits from manifest and overrides them with flags.
+func (p *RunCmd) getResourceLimits(m *hub.Manifest) (limits management.ResourceLimits, err error) {
+	if m != nil {
+		limits, err = manifest.GetResourceLimits(m.GetSpec())
+		if err != nil {
+			return management.ResourceLimits{}, fmt.Errorf("cannot get manifest resource limits: %w", err)
+		}
+	}
+
+	flagLimits := management.ResourceLimits{
+		Cpus:      p.cpus,
+		PidsLimit: p.pidsLimit,
+	}
+	flagLimits.Memory, err = management.ParseSize(p.memory)
+	if err != nil {
+		return management.ResourceLimits{}, fmt.Errorf("'memory' must be valid size: %w", err)
+	}
+	flagLimits.MemorySwap, err = management.ParseSize(p.memorySwap)
+	if err != nil {
+		return management.ResourceLimits{}, fmt.Errorf("'memorySwap' must be valid size: %w", err)
+	}
+	flagLimits.ShmSize, err = management.ParseSize(p.shmSize)
+	if err != nil {
+		return management.ResourceLimits{}, fmt.Errorf("'shmSize' must be valid size: %w", err)
+	}
+	for _, item := range p.ulimits.StringSlice {
+		var ulimit management.Ulimit
+		ulimit, err = management.ParseUlimit(item)
+		if err != nil {
+			return management.ResourceLimits{}, fmt.Errorf("'ulimit' must be valid: %w", err)
+		}
+		flagLimits.Ulimits = append(flagLimits.Ulimits, ulimit)
+	}
+
+	limits = limits.Override(flagLimits)
+	if limits.ShmSize == 0 {
+		limits.ShmSize = defaultShmSize
+	}
+	return limits, nil
+}
+
+// getSecurityOptions takes security profile from flags, manifest or debug one
+// and applies manifest and then flags explicit options on top of it.
+func (p *RunCmd) getSecurityOptions(m *hub.Manifest) (options management.SecurityOptions, err error) {
+	profile := management.SecurityProfile(p.securityProfile)
+	if profile == "" && m != nil {
+		profile = manifest.GetSecurityProfile(m.GetSpec())
+	}
+	if profile == "" {
+		profile = management.SecurityProfileDebug
+	}
+
+	options, err = management.GetSecurityOptions(profile)
+	if err != nil {
+		return management.SecurityOptions{}, err
+	}
+
+	if m != nil {
+		vars := getManifestVariables(p.imageTag, p.hostWorkDirPath, p.hostHomeDir, p.user)
+		options = options.Override(manifest.GetSecurityOptions(m.GetSpec(), vars))
+	}
+
+	options = options.Override(management.SecurityOptions{
+		CapAdd:         p.capAdd.StringSlice,
+		CapDrop:        p.capDrop.StringSlice,
+		SeccompProfile: p.seccompProfile,
+	})
+
+	// Container manager may resolve relative path from another directory.
+	if options.SeccompProfile != "" && options.SeccompProfile != management.SeccompUnconfined {
+		options.SeccompProfile, err = filepath.Abs(options.SeccompProfile)
+		if err != nil {
+			return management.SecurityOptions{}, fmt.Errorf("cannot get absolute path of seccomp profile: %w", err)
+		}
+	}
+	return options, nil
+}
+
+// getManifest returns manifest file or image manifest with devContainerManifest merged over it.
+func (p *RunCmd) getManifest(
+	ctx context.Context,
+	manager management.ContainerManager,
+	devContainerManifest *hub.Manifest,
+) (m *hub.Manifest, err error) {
+	m, err = p.getImageManifest(ctx, manager)
+	if err != nil {
+		return nil, err
+	}
+
+	if devContainerManifest == nil {
+		return m, nil
+	}
+	if m == nil {
+		return devContainerManifest, nil
+	}
+	manifest.Merge(m, devContainerManifest)
+	return m, nil
+}
+
+func (p *RunCmd) getImageManifest(ctx context.Context, manager management.ContainerManager) (m *hub.Manifest, err error) {
+	if p.manifestPath != "" {
+		m, err = manifest.LoadFile(p.manifestPath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load manifest: %w", err)
+		}
+		return m, nil
+	}
+
+	var labels []management.Label
+	labels, err = manager.GetImageLabels(ctx, p.imageTag)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get image labels: %w", err)
+	}
+
+	m, err = manifest.FindInLabels(labels)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load manifest from image '%s': %w", p.imageTag, err)
+	}
+	if m != nil {
+		log.Printf("using manifest from image '%s' label '%s'\n", p.imageTag, manifest.ImageLabel)
+	}
+	return m, nil
+}
+
+// applyDevContainer takes image and user from devcontainer.json unless they are set by flags
+// and returns its manifest.
+func (p *RunCmd) applyDevContainer(f *flag.FlagSet) (m *hub.Manifest, err error) {
+	var config *devcontainer.Config
+	config, m, err = loadDevContainer(p.devContainerPath, p.hostWorkDirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.imageTag == "" {
+		p.imageTag = config.Image
+	}
+	if p.imageTag == "" {
+		return nil, fmt.Errorf("'image' must be set with image tag or devcontainer must have image")
+	}
+
+	userSet := false
+	f.Visit(func(item *flag.Flag) {
+		if item.Name == "user" {
+			userSet = true
+		}
+	})
+	if !userSet && config.RemoteUser != "" {
+		p.user = config.RemoteUser
+	}
+	return m, nil
+}
+
 func (p *RunCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
 	err := p.execute(ctx, f)
 	if err != nil {
@@ -139,8 +592,7 @@ func getMountPoints(
 	hostHomeDir string,
 	userInsideContainer string,
 ) (mountPoints []management.MountPoint, err error) {
-	devHomeDirName := naming.GenDevHomeDirName(imageTag, hostWorkDir)
-	devHomeDir := filepath.Join(hostWorkDir, "..", devHomeDirName)
+	devHomeDir := getDevHomeDir(imageTag, hostWorkDir)
 
 	homeDirInsideContainer := fmt.Sprintf("/home/%s", userInsideContainer)
 
@@ -150,8 +602,6 @@ func getMountPoints(
 		filepath.Join(homeDirInsideContainer, ".java"):   filepath.Join(devHomeDir, ".java"),
 		filepath.Join(homeDirInsideContainer, ".jdks"):   filepath.Join(devHomeDir, ".jdks"),
 		filepath.Join(homeDirInsideContainer, ".local"):  filepath.Join(devHomeDir, ".local"),
-		filepath.Join(homeDirInsideContainer, ".m2"):     filepath.Join(devHomeDir, ".m2"),
-		filepath.Join(homeDirInsideContainer, "go"):      filepath.Join(devHomeDir, "go"),
 		filepath.Join("/", "work"):                       hostWorkDir,
 	}
 	for containerDirPath, hostDirPath := range dirsMustBeMounted {
@@ -168,6 +618,24 @@ func getMountPoints(
 		)
 	}
 
+	// Caches are shared between containers in runtime managed volumes. Images create the mount
+	// points owned by their own user only, the runtime would create them owned by root for others.
+	volumesMustBeMounted := map[string]string{}
+	if userInsideContainer == defaultContainerUser {
+		volumesMustBeMounted[filepath.Join(homeDirInsideContainer, ".m2")] = "dev-runner-m2"
+		volumesMustBeMounted[filepath.Join(homeDirInsideContainer, "go")] = "dev-runner-go"
+	}
+	for containerDirPath, volumeName := range volumesMustBeMounted {
+		mountPoints = append(
+			mountPoints,
+			management.MountPoint{
+				Type:          management.MountVolume,
+				VolumeName:    volumeName,
+				ContainerPath: containerDirPath,
+			},
+		)
+	}
+
 	filesMustBeMounted := map[string]string{
 		filepath.Join(homeDirInsideContainer, ".bash_history"): filepath.Join(devHomeDir, ".bash_history"),
 	}
@@ -222,6 +690,86 @@ func getMountPoints(
 	return mountPoints, nil
 }
 
+func getManifestVariables(
+	imageTag string,
+	hostWorkDir string,
+	hostHomeDir string,
+	userInsideContainer string,
+) manifest.Variables {
+	return manifest.Variables{
+		"HOME":                 hostHomeDir,
+		"DEV_RUNNER_HOME_DIR":  getDevHomeDir(imageTag, hostWorkDir),
+		"DEV_RUNNER_WORK_DIR":  hostWorkDir,
+		"DEV_RUNNER_USER":      userInsideContainer,
+		"DEV_RUNNER_IMAGE_TAG": imageTag,
+	}
+}
+
+func getManifestSpec(
+	m *hub.Manifest,
+	imageTag string,
+	hostWorkDir string,
+	hostHomeDir string,
+	userInsideContainer string,
+) (
+	mountPoints []management.MountPoint,
+	environmentVariables []management.EnvironmentVariable,
+	portBindings []management.PortBinding,
+	err error,
+) {
+	vars := getManifestVariables(imageTag, hostWorkDir, hostHomeDir, userInsideContainer)
+
+	mountPoints, err = manifest.GetMountPoints(m.GetSpec(), vars)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot get mount points: %w", err)
+	}
+	// Manifest may mount the work dir itself, the runtime rejects duplicate mount points.
+	workDirInsideContainer := filepath.Join("/", "work")
+	manifestMountsWorkDir := slices.ContainsFunc(mountPoints, func(mountPoint management.MountPoint) bool {
+		return filepath.Clean(mountPoint.ContainerPath) == workDirInsideContainer
+	})
+	if !manifestMountsWorkDir {
+		mountPoints = append(
+			mountPoints,
+			management.MountPoint{
+				HostPath:      hostWorkDir,
+				ContainerPath: workDirInsideContainer,
+			},
+		)
+	}
+
+	environmentVariables = manifest.GetEnvironmentVariables(m.GetSpec(), vars)
+	portBindings = manifest.GetPortBindings(m.GetSpec())
+
+	return mountPoints, environmentVariables, portBindings, nil
+}
+
+// getAuthorizedKeysMountPoint mounts generated container key to the path
+// listed in dev images sshd 'AuthorizedKeysFile'.
+func getAuthorizedKeysMountPoint(containerName string, userInsideContainer string) (mountPoint management.MountPoint, err error) {
+	var keyPair ssh.KeyPair
+	keyPair, err = getContainerKeyPair(containerName)
+	if err != nil {
+		return management.MountPoint{}, err
+	}
+
+	return management.MountPoint{
+		HostPath:      keyPair.AuthorizedKeysPath,
+		ContainerPath: filepath.Join("/", "etc", "ssh", "authorized_keys.d", userInsideContainer),
+		ReadOnly:      true,
+	}, nil
+}
+
+// resolveHostAddress returns IP address to bind container ports to.
+func resolveHostAddress(host string) (address string, err error) {
+	var ipAddr *net.IPAddr
+	ipAddr, err = net.ResolveIPAddr("ip", host)
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve host '%s': %w", host, err)
+	}
+	return ipAddr.String(), nil
+}
+
 func getNetworkMode(value string) (networkMode management.NetworkMode, err error) {
 	for _, item := range management.GetNetworkModes() {
 		if string(item) == value {
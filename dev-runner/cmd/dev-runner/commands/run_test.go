@@ -0,0 +1,412 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/manifest"
+	"dev-runner/pkg/dev/manifest/hub"
+	"dev-runner/pkg/dev/naming"
+	"dev-runner/pkg/dev/ports"
+
+	devlabels "dev-runner/pkg/dev/labels"
+)
+
+const testImageTag = "dev-test:1"
+
+func runTestContainer(t *testing.T, workDir string, homeDir string, args ...string) {
+	t.Helper()
+
+	args = append([]string{
+		"-image", testImageTag,
+		"-workDir", workDir,
+		"-homeDir", homeDir,
+		"-containerSshPort", "2345",
+	}, args...)
+	err := executeCommand(t, &RunCmd{}, args...)
+	if err != nil {
+		t.Fatalf("run failed: %s", err)
+	}
+}
+
+func TestRunCmdWithoutManifest(t *testing.T) {
+	manager := setupFakeManager(t)
+	manager.AddImage(testImageTag, nil)
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+
+	runTestContainer(t, workDir, homeDir, "-network", "bridge", "-host", "127.0.0.1")
+
+	calls := manager.RunContainerCalls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 RunContainer call, got %d", len(calls))
+	}
+	call := calls[0]
+
+	if call.ImageName != testImageTag {
+		t.Errorf("image: expected '%s', got '%s'", testImageTag, call.ImageName)
+	}
+	if expected := naming.GenContainerName(testImageTag, workDir); call.ContainerName != expected {
+		t.Errorf("container name: expected '%s', got '%s'", expected, call.ContainerName)
+	}
+	if call.NetworkMode != management.NetworkBridge {
+		t.Errorf("network mode: expected '%s', got '%s'", management.NetworkBridge, call.NetworkMode)
+	}
+
+	mountPoint, ok := findMountPoint(call.MountPoints, "/work")
+	if !ok || mountPoint.HostPath != workDir {
+		t.Errorf("work dir mount point: expected host path '%s', got %+v", workDir, mountPoint)
+	}
+	mountPoint, ok = findMountPoint(call.MountPoints, "/home/user/.m2")
+	if !ok || mountPoint.GetType() != management.MountVolume || mountPoint.VolumeName != "dev-runner-m2" {
+		t.Errorf("maven cache mount point: expected volume 'dev-runner-m2', got %+v", mountPoint)
+	}
+	mountPoint, ok = findMountPoint(call.MountPoints, "/etc/ssh/authorized_keys.d/user")
+	if !ok || !mountPoint.ReadOnly {
+		t.Errorf("authorized keys mount point: expected read only, got %+v", mountPoint)
+	}
+
+	if value, _ := findEnvironmentVariable(call.EnvironmentVariables, sshPortEnvironmentVariable); value != "2345" {
+		t.Errorf("env '%s': expected '2345', got '%s'", sshPortEnvironmentVariable, value)
+	}
+	if value, _ := findEnvironmentVariable(call.EnvironmentVariables, sshPasswordAuthenticationEnvironmentVariable); value != "no" {
+		t.Errorf("env '%s': expected 'no', got '%s'", sshPasswordAuthenticationEnvironmentVariable, value)
+	}
+
+	expectedPortBinding := management.PortBinding{ContainerPort: 2345, HostPort: 2345, HostAddress: "127.0.0.1"}
+	if !slices.Contains(call.PortBindings, expectedPortBinding) {
+		t.Errorf("port bindings: expected %+v in %+v", expectedPortBinding, call.PortBindings)
+	}
+
+	expectedLabels := map[string]string{
+		devlabels.ImageTag: testImageTag,
+		devlabels.WorkDir:  naming.ResolveWorkDir(workDir),
+		devlabels.SshPort:  "2345",
+		devlabels.User:     "user",
+	}
+	for name, expected := range expectedLabels {
+		if value, _ := findLabel(call.Labels, name); value != expected {
+			t.Errorf("label '%s': expected '%s', got '%s'", name, expected, value)
+		}
+	}
+	if _, ok = findLabel(call.Labels, manifest.ImageLabel); ok {
+		t.Errorf("label '%s' must not be set without manifest", manifest.ImageLabel)
+	}
+}
+
+func TestRunCmdWithImageManifest(t *testing.T) {
+	manager := setupFakeManager(t)
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+
+	m := &hub.Manifest{
+		Kind:    proto.String(manifest.Kind),
+		Version: proto.String(manifest.Version),
+		Spec: &hub.Spec{
+			MountPoints: []*hub.MountPoint{
+				{
+					HostPath:      proto.String("${DEV_RUNNER_HOME_DIR}/.cache"),
+					ContainerPath: proto.String("/home/${DEV_RUNNER_USER}/.cache"),
+					Type:          hub.MountPoint_Directory.Enum(),
+					NeedCreate:    proto.Bool(true),
+				},
+				{
+					HostPath:      proto.String("${HOME}/.missing"),
+					ContainerPath: proto.String("/home/${DEV_RUNNER_USER}/.missing"),
+					Type:          hub.MountPoint_Directory.Enum(),
+				},
+			},
+			EnvironmentVariables: []*hub.EnvironmentVariable{
+				{Name: proto.String("IMAGE"), Value: proto.String("${DEV_RUNNER_IMAGE_TAG}")},
+			},
+			PortBindings: []*hub.PortBinding{
+				{HostPort: proto.Int32(8080), ContainerPort: proto.Int32(80)},
+			},
+		},
+	}
+	label, err := manifest.ToLabel(m)
+	if err != nil {
+		t.Fatalf("cannot encode manifest: %s", err)
+	}
+	manager.AddImage(testImageTag, []management.Label{label})
+
+	runTestContainer(t, workDir, homeDir)
+
+	calls := manager.RunContainerCalls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 RunContainer call, got %d", len(calls))
+	}
+	call := calls[0]
+
+	devHomeDir := getDevHomeDir(testImageTag, workDir)
+	mountPoint, ok := findMountPoint(call.MountPoints, "/home/user/.cache")
+	if !ok || mountPoint.HostPath != filepath.Join(devHomeDir, ".cache") {
+		t.Errorf("cache mount point: expected host path '%s', got %+v", filepath.Join(devHomeDir, ".cache"), mountPoint)
+	}
+	if _, err = os.Stat(filepath.Join(devHomeDir, ".cache")); err != nil {
+		t.Errorf("cache dir must be created: %s", err)
+	}
+	if _, ok = findMountPoint(call.MountPoints, "/home/user/.missing"); ok {
+		t.Errorf("mount point of missing optional dir must be skipped")
+	}
+	if _, ok = findMountPoint(call.MountPoints, "/work"); !ok {
+		t.Errorf("work dir mount point must be added")
+	}
+
+	if value, _ := findEnvironmentVariable(call.EnvironmentVariables, "IMAGE"); value != testImageTag {
+		t.Errorf("env 'IMAGE': expected '%s', got '%s'", testImageTag, value)
+	}
+
+	expectedPortBinding := management.PortBinding{ContainerPort: 80, HostPort: 8080}
+	if !slices.Contains(call.PortBindings, expectedPortBinding) {
+		t.Errorf("port bindings: expected %+v in %+v", expectedPortBinding, call.PortBindings)
+	}
+
+	if value, _ := findLabel(call.Labels, manifest.ImageLabel); value != label.Value {
+		t.Errorf("label '%s' must keep the manifest", manifest.ImageLabel)
+	}
+	if call.ResourceLimits.ShmSize != defaultShmSize {
+		t.Errorf("shm size: expected default %d, got %d", defaultShmSize, call.ResourceLimits.ShmSize)
+	}
+}
+
+func TestRunCmdFailsOnMissingImageWithoutPull(t *testing.T) {
+	manager := setupFakeManager(t)
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+
+	err := executeCommand(t, &RunCmd{}, "-image", testImageTag, "-workDir", workDir, "-homeDir", homeDir, "-pull", "never")
+	if err == nil {
+		t.Fatalf("run must fail when image is missing and pull is disabled")
+	}
+	if calls := manager.RunContainerCalls(); len(calls) != 0 {
+		t.Errorf("expected no RunContainer calls, got %d", len(calls))
+	}
+}
+
+func TestGetMountPoints(t *testing.T) {
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+
+	err := os.MkdirAll(filepath.Join(homeDir, ".ssh"), 0o700)
+	if err != nil {
+		t.Fatalf("cannot create .ssh dir: %s", err)
+	}
+	err = os.WriteFile(filepath.Join(homeDir, ".gitconfig"), nil, 0o600)
+	if err != nil {
+		t.Fatalf("cannot create .gitconfig: %s", err)
+	}
+
+	mountPoints, err := getMountPoints(testImageTag, workDir, homeDir, "dev")
+	if err != nil {
+		t.Fatalf("getMountPoints failed: %s", err)
+	}
+
+	devHomeDir := getDevHomeDir(testImageTag, workDir)
+	expectedBinds := map[string]string{
+		"/work":                   workDir,
+		"/home/dev/.cache":        filepath.Join(devHomeDir, ".cache"),
+		"/home/dev/.config":       filepath.Join(devHomeDir, ".config"),
+		"/home/dev/.java":         filepath.Join(devHomeDir, ".java"),
+		"/home/dev/.jdks":         filepath.Join(devHomeDir, ".jdks"),
+		"/home/dev/.local":        filepath.Join(devHomeDir, ".local"),
+		"/home/dev/.bash_history": filepath.Join(devHomeDir, ".bash_history"),
+		"/home/dev/.ssh":          filepath.Join(homeDir, ".ssh"),
+		"/home/dev/.gitconfig":    filepath.Join(homeDir, ".gitconfig"),
+	}
+	for containerPath, hostPath := range expectedBinds {
+		mountPoint, ok := findMountPoint(mountPoints, containerPath)
+		if !ok {
+			t.Errorf("mount point '%s' is missing", containerPath)
+			continue
+		}
+		if mountPoint.GetType() != management.MountBind || mountPoint.HostPath != hostPath {
+			t.Errorf("mount point '%s': expected bind of '%s', got %+v", containerPath, hostPath, mountPoint)
+		}
+		if _, err = os.Stat(hostPath); err != nil {
+			t.Errorf("mount point '%s' host path must exist: %s", containerPath, err)
+		}
+	}
+
+	// Images create the cache dirs only for their default user.
+	for _, containerPath := range []string{"/home/dev/.m2", "/home/dev/go"} {
+		if _, ok := findMountPoint(mountPoints, containerPath); ok {
+			t.Errorf("cache volume '%s' must be skipped for non default user", containerPath)
+		}
+	}
+
+	if _, ok := findMountPoint(mountPoints, "/home/dev/.docker"); ok {
+		t.Errorf("mount point of missing .docker dir must be skipped")
+	}
+
+	expectedCount := len(expectedBinds)
+	if fileExists("/var/run/docker.sock") {
+		expectedCount++
+	}
+	if len(mountPoints) != expectedCount {
+		t.Errorf("expected %d mount points, got %d: %+v", expectedCount, len(mountPoints), mountPoints)
+	}
+}
+
+func TestGetMountPointsMountsCachesForDefaultUser(t *testing.T) {
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+
+	mountPoints, err := getMountPoints(testImageTag, workDir, homeDir, defaultContainerUser)
+	if err != nil {
+		t.Fatalf("getMountPoints failed: %s", err)
+	}
+
+	expectedVolumes := map[string]string{
+		"/home/user/.m2": "dev-runner-m2",
+		"/home/user/go":  "dev-runner-go",
+	}
+	for containerPath, volumeName := range expectedVolumes {
+		mountPoint, ok := findMountPoint(mountPoints, containerPath)
+		if !ok || mountPoint.GetType() != management.MountVolume || mountPoint.VolumeName != volumeName {
+			t.Errorf("mount point '%s': expected volume '%s', got %+v", containerPath, volumeName, mountPoint)
+		}
+	}
+}
+
+func TestGetManifestSpecKeepsManifestWorkDirMount(t *testing.T) {
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+	m := &hub.Manifest{
+		Kind:    proto.String(manifest.Kind),
+		Version: proto.String(manifest.Version),
+		Spec: &hub.Spec{
+			MountPoints: []*hub.MountPoint{
+				{HostPath: proto.String(workDir), ContainerPath: proto.String("/work/"), ReadOnly: proto.Bool(true)},
+			},
+		},
+	}
+
+	mountPoints, _, _, err := getManifestSpec(m, testImageTag, workDir, homeDir, defaultContainerUser)
+	if err != nil {
+		t.Fatalf("getManifestSpec failed: %s", err)
+	}
+	if len(mountPoints) != 1 || !mountPoints[0].ReadOnly {
+		t.Errorf("expected only manifest read only work dir mount point, got %+v", mountPoints)
+	}
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func TestRunCmdRegistersSshPortOnlyForCreatedContainer(t *testing.T) {
+	manager := setupFakeManager(t)
+	manager.AddImage(testImageTag, nil)
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+	runTestContainer(t, workDir, homeDir)
+	containerName := naming.GenContainerName(testImageTag, workDir)
+
+	// The container name is in use, so the second run fails and must keep the first port.
+	err := executeCommand(t, &RunCmd{},
+		"-image", testImageTag,
+		"-workDir", workDir,
+		"-homeDir", homeDir,
+		"-containerSshPort", "3456",
+	)
+	if err == nil {
+		t.Fatalf("run must fail when container exists")
+	}
+
+	registry, err := ports.LoadDefaultRegistry()
+	if err != nil {
+		t.Fatalf("cannot load ports registry: %s", err)
+	}
+	port, ok := registry.Lookup(containerName)
+	if !ok || port != 2345 {
+		t.Errorf("registered SSH port: expected 2345, got %d (registered: %t)", port, ok)
+	}
+}
+
+func TestRunCmdDevContainerOverridesImageManifest(t *testing.T) {
+	manager := setupFakeManager(t)
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+	cacheDir := newTestDir(t, "cache")
+
+	m := &hub.Manifest{
+		Kind:    proto.String(manifest.Kind),
+		Version: proto.String(manifest.Version),
+		Spec: &hub.Spec{
+			MountPoints: []*hub.MountPoint{
+				{
+					HostPath:      proto.String("${DEV_RUNNER_HOME_DIR}/.cache"),
+					ContainerPath: proto.String("/home/user/.cache"),
+					Type:          hub.MountPoint_Directory.Enum(),
+					NeedCreate:    proto.Bool(true),
+				},
+			},
+			EnvironmentVariables: []*hub.EnvironmentVariable{
+				{Name: proto.String("MODE"), Value: proto.String("image")},
+				{Name: proto.String("IMAGE"), Value: proto.String("${DEV_RUNNER_IMAGE_TAG}")},
+			},
+			PortBindings: []*hub.PortBinding{
+				{HostPort: proto.Int32(8080), ContainerPort: proto.Int32(3000)},
+			},
+		},
+	}
+	label, err := manifest.ToLabel(m)
+	if err != nil {
+		t.Fatalf("cannot encode manifest: %s", err)
+	}
+	manager.AddImage(testImageTag, []management.Label{label})
+
+	devContainerPath := filepath.Join(workDir, "devcontainer.json")
+	devContainer := `{
+		"mounts": ["source=` + cacheDir + `,target=/home/user/.cache,type=bind"],
+		"containerEnv": {"MODE": "devcontainer"},
+		"forwardPorts": [3000]
+	}`
+	err = os.WriteFile(devContainerPath, []byte(devContainer), 0o600)
+	if err != nil {
+		t.Fatalf("cannot write devcontainer file: %s", err)
+	}
+
+	runTestContainer(t, workDir, homeDir, "-devcontainer", devContainerPath)
+
+	calls := manager.RunContainerCalls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 RunContainer call, got %d", len(calls))
+	}
+	call := calls[0]
+
+	var cacheMountPoints []management.MountPoint
+	for _, item := range call.MountPoints {
+		if item.ContainerPath == "/home/user/.cache" {
+			cacheMountPoints = append(cacheMountPoints, item)
+		}
+	}
+	if len(cacheMountPoints) != 1 || cacheMountPoints[0].HostPath != cacheDir {
+		t.Errorf("cache mount points: expected single one from '%s', got %+v", cacheDir, cacheMountPoints)
+	}
+
+	var modes []string
+	for _, item := range call.EnvironmentVariables {
+		if item.Name == "MODE" {
+			modes = append(modes, item.Value)
+		}
+	}
+	if !slices.Equal(modes, []string{"devcontainer"}) {
+		t.Errorf("env 'MODE': expected single devcontainer value, got %v", modes)
+	}
+	if value, _ := findEnvironmentVariable(call.EnvironmentVariables, "IMAGE"); value != testImageTag {
+		t.Errorf("env 'IMAGE': expected '%s', got '%s'", testImageTag, value)
+	}
+
+	expectedPortBindings := []management.PortBinding{{ContainerPort: 3000, HostPort: 3000, HostAddress: "127.0.0.1"}}
+	if !slices.Equal(call.PortBindings, expectedPortBindings) {
+		t.Errorf("port bindings: expected %+v, got %+v", expectedPortBindings, call.PortBindings)
+	}
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/hooks"
+
+	"github.com/google/subcommands"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+type StartCmd struct {
+	containerManagerName string
+	connection           connectionFlags
+	imageTag             string
+	hostWorkDirPath      string
+}
+
+func (*StartCmd) Name() string {
+	return "start"
+}
+
+func (*StartCmd) Synopsis() string {
+	return "start."
+}
+
+func (*StartCmd) Usage() string {
+	return `
+`
+}
+
+func (p *StartCmd) SetFlags(f *flag.FlagSet) {
+	workDir, _ := os.Getwd()
+
+	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
+	f.StringVar(&p.imageTag, "image", "", "Dev image tag.")
+	f.StringVar(&p.hostWorkDirPath, "workDir", workDir, "Work dir on host to mount inside container.")
+}
+
+func (p *StartCmd) validateCliArguments() (err error) {
+	if p.imageTag == "" {
+		return fmt.Errorf("'image' must be set with image tag")
+	}
+	if !fp.IsDir(p.hostWorkDirPath) {
+		return fmt.Errorf("'workDir' must be exists and be directory")
+	}
+	return nil
+}
+
+func (p *StartCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
+	err = p.validateCliArguments()
+	if err != nil {
+		return fmt.Errorf("command line validation failed: %w", err)
+	}
+
+	var manager management.ContainerManager
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
+	if err != nil {
+		return fmt.Errorf("cannot create container manager: %w", err)
+	}
+
+	err = manager.Init(ctx)
+	if err != nil {
+		return fmt.Errorf("container manager initialization failed: %w", err)
+	}
+
+	var containerName string
+	containerName, err = findContainerName(ctx, manager, p.imageTag, p.hostWorkDirPath)
+	if err != nil {
+		return fmt.Errorf("cannot find container: %w", err)
+	}
+
+	err = manager.StartContainer(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("start container failed: %w", err)
+	}
+
+	err = runContainerHooks(ctx, manager, containerName, hooks.PostStart)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *StartCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	err := p.execute(ctx, f)
+	if err != nil {
+		log.Fatalf("got error: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
@@ -2,14 +2,13 @@ package commands
 
 import (
 	"context"
-	"dev-runner/pkg/dev/naming"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 
 	"dev-runner/pkg/conainer/management"
-	"dev-runner/pkg/conainer/management/creator"
+	"dev-runner/pkg/dev/hooks"
 
 	"github.com/google/subcommands"
 
@@ -18,8 +17,11 @@ import (
 
 type StopCmd struct {
 	containerManagerName string
+	connection           connectionFlags
 	imageTag             string
 	hostWorkDirPath      string
+	timeout              int
+	keep                 bool
 }
 
 func (*StopCmd) Name() string {
@@ -39,8 +41,11 @@ func (p *StopCmd) SetFlags(f *flag.FlagSet) {
 	workDir, _ := os.Getwd()
 
 	f.StringVar(&p.containerManagerName, "cm", "docker", "Containers manager. Values: docker or podman.")
+	setConnectionFlags(f, &p.connection)
 	f.StringVar(&p.imageTag, "image", "", "Dev image tag.")
 	f.StringVar(&p.hostWorkDirPath, "workDir", workDir, "Work dir on host to mount inside container.")
+	f.IntVar(&p.timeout, "timeout", -1, "Seconds to wait for container to stop before killing it, -1 uses container manager default.")
+	f.BoolVar(&p.keep, "keep", false, "Keep stopped container to resume it with 'start' later.")
 }
 
 func (p *StopCmd) validateCliArguments() (err error) {
@@ -60,7 +65,7 @@ func (p *StopCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
 	}
 
 	var manager management.ContainerManager
-	manager, err = creator.CreateContainerManager(p.containerManagerName)
+	manager, err = newContainerManager(p.containerManagerName, p.connection)
 	if err != nil {
 		return fmt.Errorf("cannot create container manager: %w", err)
 	}
@@ -70,13 +75,36 @@ func (p *StopCmd) execute(ctx context.Context, _ *flag.FlagSet) (err error) {
 		return fmt.Errorf("container manager initialization failed: %w", err)
 	}
 
-	containerName := naming.GenContainerName(p.imageTag, p.hostWorkDirPath)
+	var containerName string
+	containerName, err = findContainerName(ctx, manager, p.imageTag, p.hostWorkDirPath)
+	if err != nil {
+		return fmt.Errorf("cannot find container: %w", err)
+	}
 
-	err = manager.StopContainer(ctx, containerName)
+	err = runContainerHooks(ctx, manager, containerName, hooks.PreStop)
+	if err != nil {
+		return err
+	}
+
+	err = manager.StopContainer(ctx, containerName, p.timeout)
 	if err != nil {
 		return fmt.Errorf("stop container failed: %w", err)
 	}
 
+	if p.keep {
+		return nil
+	}
+
+	err = manager.RemoveContainer(ctx, containerName, false)
+	if err != nil {
+		return fmt.Errorf("remove container failed: %w", err)
+	}
+
+	err = forgetContainer(containerName)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"dev-runner/pkg/conainer/management/fake"
+	"dev-runner/pkg/dev/naming"
+	"dev-runner/pkg/dev/ports"
+)
+
+func TestStopCmdRemovesContainer(t *testing.T) {
+	manager := setupFakeManager(t)
+	manager.AddImage(testImageTag, nil)
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+	runTestContainer(t, workDir, homeDir)
+	containerName := naming.GenContainerName(testImageTag, workDir)
+
+	err := executeCommand(t, &StopCmd{}, "-image", testImageTag, "-workDir", workDir, "-timeout", "5")
+	if err != nil {
+		t.Fatalf("stop failed: %s", err)
+	}
+
+	stopCalls := manager.StopContainerCalls()
+	expectedStopCall := fake.StopContainerCall{ContainerName: containerName, Timeout: 5}
+	if len(stopCalls) != 1 || stopCalls[0] != expectedStopCall {
+		t.Errorf("StopContainer calls: expected [%+v], got %+v", expectedStopCall, stopCalls)
+	}
+
+	removeCalls := manager.RemoveContainerCalls()
+	expectedRemoveCall := fake.RemoveContainerCall{ContainerName: containerName, Force: false}
+	if len(removeCalls) != 1 || removeCalls[0] != expectedRemoveCall {
+		t.Errorf("RemoveContainer calls: expected [%+v], got %+v", expectedRemoveCall, removeCalls)
+	}
+
+	exists, _ := manager.IsContainerExists(context.Background(), containerName)
+	if exists {
+		t.Errorf("container '%s' must be removed", containerName)
+	}
+
+	registry, err := ports.LoadDefaultRegistry()
+	if err != nil {
+		t.Fatalf("cannot load ports registry: %s", err)
+	}
+	if port, ok := registry.Lookup(containerName); ok {
+		t.Errorf("SSH port %d of removed container must be released", port)
+	}
+}
+
+func TestStopCmdKeepsContainer(t *testing.T) {
+	manager := setupFakeManager(t)
+	manager.AddImage(testImageTag, nil)
+	workDir := newTestDir(t, "project")
+	homeDir := newTestDir(t, "home")
+	runTestContainer(t, workDir, homeDir)
+	containerName := naming.GenContainerName(testImageTag, workDir)
+
+	err := executeCommand(t, &StopCmd{}, "-image", testImageTag, "-workDir", workDir, "-keep")
+	if err != nil {
+		t.Fatalf("stop failed: %s", err)
+	}
+
+	stopCalls := manager.StopContainerCalls()
+	expectedStopCall := fake.StopContainerCall{ContainerName: containerName, Timeout: -1}
+	if len(stopCalls) != 1 || stopCalls[0] != expectedStopCall {
+		t.Errorf("StopContainer calls: expected [%+v], got %+v", expectedStopCall, stopCalls)
+	}
+	if removeCalls := manager.RemoveContainerCalls(); len(removeCalls) != 0 {
+		t.Errorf("expected no RemoveContainer calls, got %+v", removeCalls)
+	}
+
+	exists, _ := manager.IsContainerExists(context.Background(), containerName)
+	if !exists {
+		t.Errorf("container '%s' must be kept", containerName)
+	}
+}
+
+func TestStopCmdFailsWithoutContainer(t *testing.T) {
+	manager := setupFakeManager(t)
+	workDir := newTestDir(t, "project")
+
+	err := executeCommand(t, &StopCmd{}, "-image", testImageTag, "-workDir", workDir)
+	if err == nil {
+		t.Fatalf("stop must fail when container does not exist")
+	}
+	if removeCalls := manager.RemoveContainerCalls(); len(removeCalls) != 0 {
+		t.Errorf("expected no RemoveContainer calls, got %+v", removeCalls)
+	}
+}
@@ -15,9 +15,16 @@ func main() {
 	subcommands.Register(subcommands.FlagsCommand(), "")
 	subcommands.Register(subcommands.CommandsCommand(), "")
 	subcommands.Register(&commands.AttachCmd{}, "")
+	subcommands.Register(&commands.BuildCmd{}, "")
+	subcommands.Register(&commands.DevContainerCmd{}, "")
+	subcommands.Register(&commands.ExecCmd{}, "")
+	subcommands.Register(&commands.ImagesCmd{}, "")
 	subcommands.Register(&commands.LoadCmd{}, "")
 	subcommands.Register(&commands.LogsCmd{}, "")
+	subcommands.Register(&commands.PsCmd{}, "")
+	subcommands.Register(&commands.RmCmd{}, "")
 	subcommands.Register(&commands.RunCmd{}, "")
+	subcommands.Register(&commands.StartCmd{}, "")
 	subcommands.Register(&commands.StopCmd{}, "")
 
 	flag.Parse()
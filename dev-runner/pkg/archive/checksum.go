@@ -0,0 +1,52 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// VerifySha256 checks the file against sidecar file in sha256sum format.
+func VerifySha256(path string, sidecarPath string) (err error) {
+	var sidecar []byte
+	sidecar, err = os.ReadFile(sidecarPath)
+	if err != nil {
+		return fmt.Errorf("cannot read checksum file '%s': %w", sidecarPath, err)
+	}
+
+	fields := strings.Fields(string(sidecar))
+	if len(fields) == 0 {
+		return fmt.Errorf("checksum file '%s' is empty", sidecarPath)
+	}
+	expected := strings.ToLower(fields[0])
+
+	var actual string
+	actual, err = Sha256(path)
+	if err != nil {
+		return err
+	}
+
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch for '%s': expected '%s', got '%s'", path, expected, actual)
+	}
+	return nil
+}
+
+func Sha256(path string) (checksum string, err error) {
+	var f *os.File
+	f, err = os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot open file '%s': %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", fmt.Errorf("cannot read file '%s': %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,79 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+type Compression string
+
+const (
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+	CompressionXz   Compression = "xz"
+)
+
+var magics = map[Compression][]byte{
+	CompressionGzip: {0x1f, 0x8b},
+	CompressionZstd: {0x28, 0xb5, 0x2f, 0xfd},
+	CompressionXz:   {0xfd, '7', 'z', 'X', 'Z', 0x00},
+}
+
+// DetectCompression peeks the stream header without consuming it.
+func DetectCompression(r *bufio.Reader) (compression Compression, err error) {
+	for compression, magic := range magics {
+		var header []byte
+		header, err = r.Peek(len(magic))
+		if err != nil && err != io.EOF {
+			return CompressionNone, fmt.Errorf("cannot read stream header: %w", err)
+		}
+		if bytes.Equal(header, magic) {
+			return compression, nil
+		}
+	}
+	return CompressionNone, nil
+}
+
+// NewDecompressReader returns reader with the decompressed content of r,
+// the compression format is detected automatically.
+func NewDecompressReader(r io.Reader) (reader io.ReadCloser, compression Compression, err error) {
+	buffered := bufio.NewReader(r)
+
+	compression, err = DetectCompression(buffered)
+	if err != nil {
+		return nil, CompressionNone, err
+	}
+
+	switch compression {
+	case CompressionGzip:
+		var gzipReader *gzip.Reader
+		gzipReader, err = gzip.NewReader(buffered)
+		if err != nil {
+			return nil, compression, fmt.Errorf("cannot create gzip reader: %w", err)
+		}
+		return gzipReader, compression, nil
+	case CompressionZstd:
+		var zstdReader *zstd.Decoder
+		zstdReader, err = zstd.NewReader(buffered)
+		if err != nil {
+			return nil, compression, fmt.Errorf("cannot create zstd reader: %w", err)
+		}
+		return zstdReader.IOReadCloser(), compression, nil
+	case CompressionXz:
+		var xzReader *xz.Reader
+		xzReader, err = xz.NewReader(buffered)
+		if err != nil {
+			return nil, compression, fmt.Errorf("cannot create xz reader: %w", err)
+		}
+		return io.NopCloser(xzReader), compression, nil
+	default:
+		return io.NopCloser(buffered), compression, nil
+	}
+}
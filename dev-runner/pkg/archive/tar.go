@@ -0,0 +1,92 @@
+package archive
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// TarDirectory writes tar of the directory content to w,
+// extraFiles maps names inside the archive to host files added on top of it.
+func TarDirectory(w io.Writer, dir string, extraFiles map[string]string) (err error) {
+	tw := tar.NewWriter(w)
+
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		var name string
+		name, err = filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+		return addTarEntry(tw, path, filepath.ToSlash(name))
+	})
+	if err != nil {
+		return fmt.Errorf("cannot tar directory '%s': %w", dir, err)
+	}
+
+	for name, path := range extraFiles {
+		err = addTarEntry(tw, path, name)
+		if err != nil {
+			return fmt.Errorf("cannot tar file '%s': %w", path, err)
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return fmt.Errorf("cannot finish tar of directory '%s': %w", dir, err)
+	}
+	return nil
+}
+
+func addTarEntry(tw *tar.Writer, path string, name string) (err error) {
+	var info os.FileInfo
+	info, err = os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	var link string
+	if info.Mode()&os.ModeSymlink != 0 {
+		link, err = os.Readlink(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	var header *tar.Header
+	header, err = tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+
+	err = tw.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	var f *os.File
+	f, err = os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = io.Copy(tw, f)
+	return err
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const progressInterval = 200 * time.Millisecond
+
+// ProgressReader prints the amount of bytes read through it.
+type ProgressReader struct {
+	r       io.Reader
+	w       io.Writer
+	total   int64
+	read    int64
+	printed time.Time
+}
+
+func NewProgressReader(r io.Reader, w io.Writer, total int64) *ProgressReader {
+	return &ProgressReader{r: r, w: w, total: total}
+}
+
+func (p *ProgressReader) Read(b []byte) (n int, err error) {
+	n, err = p.r.Read(b)
+	p.read += int64(n)
+	if time.Since(p.printed) >= progressInterval || err == io.EOF {
+		p.print()
+	}
+	return n, err
+}
+
+// Done prints the final state and moves the cursor to the next line.
+func (p *ProgressReader) Done() {
+	p.print()
+	_, _ = fmt.Fprintln(p.w)
+}
+
+func (p *ProgressReader) print() {
+	p.printed = time.Now()
+	if p.total > 0 {
+		_, _ = fmt.Fprintf(p.w, "\r%s / %s (%d%%)", formatBytes(p.read), formatBytes(p.total), p.read*100/p.total)
+		return
+	}
+	_, _ = fmt.Fprintf(p.w, "\r%s", formatBytes(p.read))
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// MakeStdinRaw puts stdin into raw mode if it is a terminal and returns function to restore it.
+func MakeStdinRaw() (restore func(), err error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return func() {}, nil
+	}
+
+	var state *term.State
+	state, err = term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("cannot make terminal raw: %w", err)
+	}
+	return func() { _ = term.Restore(fd, state) }, nil
+}
@@ -0,0 +1,341 @@
+// Package conformance keeps RunContainer cases which docker and podman backends must translate
+// into equivalent container specs. Backend tests normalize their specs into Container and call Run.
+package conformance
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"dev-runner/pkg/conainer/management"
+)
+
+// Input keeps RunContainer arguments which affect the container spec.
+type Input struct {
+	ImageName            string
+	ContainerName        string
+	MountPoints          []management.MountPoint
+	EnvironmentVariables []management.EnvironmentVariable
+	PortBindings         []management.PortBinding
+	Labels               []management.Label
+	NetworkMode          management.NetworkMode
+	UserMapping          management.UserMapping
+	ResourceLimits       management.ResourceLimits
+	SecurityOptions      management.SecurityOptions
+	Interactive          bool
+}
+
+type Mount struct {
+	Type     management.MountType
+	Source   string
+	Target   string
+	ReadOnly bool
+	// Recursive is set for bind mounts which bring submounts of the source too, as docker binds do.
+	Recursive      bool
+	SELinuxRelabel string
+	TmpfsSize      int64
+	TmpfsMode      os.FileMode
+}
+
+// Container is backend independent view of the container spec.
+type Container struct {
+	Image        string
+	Network      management.NetworkMode
+	Mounts       []Mount
+	Env          map[string]string
+	PortBindings []management.PortBinding
+	Labels       map[string]string
+	CapAdd       []string
+	CapDrop      []string
+	// SeccompProfile is the profile content or "unconfined".
+	SeccompProfile  string
+	NoNewPrivileges bool
+	Cpus            float64
+	Memory          int64
+	MemorySwap      int64
+	PidsLimit       int64
+	ShmSize         int64
+	Ulimits         []management.Ulimit
+	Interactive     bool
+}
+
+// Build translates input into the backend spec and normalizes it.
+type Build func(input Input) (container Container, err error)
+
+type testCase struct {
+	name        string
+	input       Input
+	expected    Container
+	expectError bool
+}
+
+const (
+	imageName       = "dev-test:1"
+	containerName   = "dev-test_project_12345678"
+	dirEnvVariable  = "DEV_CONFORMANCE_DIR"
+	seccompContent  = `{"defaultAction": "SCMP_ACT_ALLOW"}`
+	hostUid         = 1234
+	hostGid         = 5678
+	containerUserId = 1000
+)
+
+// Run checks build translates every case into the expected container.
+func Run(t *testing.T, build Build) {
+	dir := t.TempDir()
+	t.Setenv(dirEnvVariable, dir)
+
+	seccompProfilePath := filepath.Join(dir, "seccomp.json")
+	err := os.WriteFile(seccompProfilePath, []byte(seccompContent), 0o600)
+	if err != nil {
+		t.Fatalf("cannot write seccomp profile: %s", err)
+	}
+
+	for _, c := range getCases(dir, seccompProfilePath) {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := build(c.input)
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("build failed: %s", err)
+			}
+
+			normalize(&actual)
+			expected := c.expected
+			normalize(&expected)
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("container mismatch\nexpected: %+v\n     got: %+v", expected, actual)
+			}
+		})
+	}
+}
+
+// NewInput returns input every case starts with.
+func NewInput() Input {
+	return Input{
+		ImageName:     imageName,
+		ContainerName: containerName,
+		NetworkMode:   management.NetworkHost,
+	}
+}
+
+func newContainer() Container {
+	return Container{
+		Image:   imageName,
+		Network: management.NetworkHost,
+		Env:     map[string]string{},
+		Labels:  map[string]string{management.ManagedLabel: "true"},
+	}
+}
+
+func getCases(dir string, seccompProfilePath string) (cases []testCase) {
+	add := func(name string, setInput func(input *Input), setExpected func(expected *Container)) {
+		input := NewInput()
+		setInput(&input)
+		expected := newContainer()
+		setExpected(&expected)
+		cases = append(cases, testCase{name: name, input: input, expected: expected})
+	}
+	addError := func(name string, setInput func(input *Input)) {
+		input := NewInput()
+		setInput(&input)
+		cases = append(cases, testCase{name: name, input: input, expectError: true})
+	}
+
+	add("defaults", func(*Input) {}, func(*Container) {})
+
+	add("binds",
+		func(input *Input) {
+			input.MountPoints = []management.MountPoint{
+				{HostPath: "${" + dirEnvVariable + "}/work", ContainerPath: "/work"},
+				{Type: management.MountBind, HostPath: "/etc/hosts", ContainerPath: "/etc/host-hosts", ReadOnly: true},
+				{HostPath: "/src", ContainerPath: "/src", SELinuxRelabel: management.SELinuxRelabelShared},
+				{HostPath: "/keys", ContainerPath: "/keys", ReadOnly: true, SELinuxRelabel: management.SELinuxRelabelPrivate},
+			}
+		},
+		func(expected *Container) {
+			expected.Mounts = []Mount{
+				{Type: management.MountBind, Source: filepath.Join(dir, "work"), Target: "/work", Recursive: true},
+				{Type: management.MountBind, Source: "/etc/hosts", Target: "/etc/host-hosts", ReadOnly: true, Recursive: true},
+				{Type: management.MountBind, Source: "/src", Target: "/src", Recursive: true, SELinuxRelabel: "z"},
+				{Type: management.MountBind, Source: "/keys", Target: "/keys", ReadOnly: true, Recursive: true, SELinuxRelabel: "Z"},
+			}
+		},
+	)
+
+	add("volumes and tmpfs",
+		func(input *Input) {
+			input.MountPoints = []management.MountPoint{
+				{Type: management.MountVolume, VolumeName: "dev-runner-m2", ContainerPath: "/home/user/.m2"},
+				{Type: management.MountVolume, VolumeName: "dev-runner-ro", ContainerPath: "/ro", ReadOnly: true},
+				{Type: management.MountTmpfs, ContainerPath: "/tmp"},
+				{Type: management.MountTmpfs, ContainerPath: "/scratch", TmpfsSize: 64 << 20, TmpfsMode: 0o1777},
+			}
+		},
+		func(expected *Container) {
+			expected.Mounts = []Mount{
+				{Type: management.MountVolume, Source: "dev-runner-m2", Target: "/home/user/.m2"},
+				{Type: management.MountVolume, Source: "dev-runner-ro", Target: "/ro", ReadOnly: true},
+				{Type: management.MountTmpfs, Target: "/tmp"},
+				{Type: management.MountTmpfs, Target: "/scratch", TmpfsSize: 64 << 20, TmpfsMode: 0o1777},
+			}
+		},
+	)
+
+	add("environment and labels",
+		func(input *Input) {
+			input.EnvironmentVariables = []management.EnvironmentVariable{
+				{Name: "PLAIN", Value: "value"},
+				{Name: "EXPANDED", Value: "${" + dirEnvVariable + "}/cache"},
+			}
+			input.Labels = []management.Label{{Name: "dev.containers.runner.image", Value: imageName}}
+		},
+		func(expected *Container) {
+			expected.Env = map[string]string{"PLAIN": "value", "EXPANDED": filepath.Join(dir, "cache")}
+			expected.Labels["dev.containers.runner.image"] = imageName
+		},
+	)
+
+	add("port bindings",
+		func(input *Input) {
+			input.NetworkMode = management.NetworkBridge
+			input.PortBindings = []management.PortBinding{
+				{ContainerPort: 2222, HostPort: 2345, HostAddress: "127.0.0.1"},
+				{ContainerPort: 80, HostPort: 8080},
+			}
+		},
+		func(expected *Container) {
+			expected.Network = management.NetworkBridge
+			expected.PortBindings = []management.PortBinding{
+				{ContainerPort: 2222, HostPort: 2345, HostAddress: "127.0.0.1"},
+				{ContainerPort: 80, HostPort: 8080},
+			}
+		},
+	)
+
+	add("user mapping",
+		func(input *Input) {
+			input.UserMapping = management.UserMapping{
+				Enabled:      true,
+				HostUid:      hostUid,
+				HostGid:      hostGid,
+				ContainerUid: containerUserId,
+			}
+		},
+		func(expected *Container) {
+			expected.Env[management.UserUidEnvironmentVariable] = "1234"
+			expected.Env[management.UserGidEnvironmentVariable] = "5678"
+		},
+	)
+
+	add("resources",
+		func(input *Input) {
+			input.ResourceLimits = management.ResourceLimits{
+				Cpus:       1.5,
+				Memory:     2 << 30,
+				MemorySwap: 4 << 30,
+				PidsLimit:  256,
+				ShmSize:    1 << 30,
+				Ulimits: []management.Ulimit{
+					{Name: "nofile", Soft: 1024, Hard: 65536},
+					{Name: "nproc", Soft: 512, Hard: 512},
+				},
+			}
+		},
+		func(expected *Container) {
+			expected.Cpus = 1.5
+			expected.Memory = 2 << 30
+			expected.MemorySwap = 4 << 30
+			expected.PidsLimit = 256
+			expected.ShmSize = 1 << 30
+			expected.Ulimits = []management.Ulimit{
+				{Name: "nofile", Soft: 1024, Hard: 65536},
+				{Name: "nproc", Soft: 512, Hard: 512},
+			}
+		},
+	)
+
+	add("security",
+		func(input *Input) {
+			input.SecurityOptions = management.SecurityOptions{
+				CapAdd:          []string{"SYS_PTRACE"},
+				CapDrop:         []string{"ALL"},
+				SeccompProfile:  seccompProfilePath,
+				NoNewPrivileges: true,
+			}
+		},
+		func(expected *Container) {
+			expected.CapAdd = []string{"SYS_PTRACE"}
+			expected.CapDrop = []string{"ALL"}
+			expected.SeccompProfile = seccompContent
+			expected.NoNewPrivileges = true
+		},
+	)
+
+	add("seccomp unconfined",
+		func(input *Input) {
+			input.SecurityOptions = management.SecurityOptions{SeccompProfile: management.SeccompUnconfined}
+		},
+		func(expected *Container) {
+			expected.SeccompProfile = management.SeccompUnconfined
+		},
+	)
+
+	add("interactive",
+		func(input *Input) {
+			input.Interactive = true
+		},
+		func(expected *Container) {
+			expected.Interactive = true
+		},
+	)
+
+	addError("unsupported mount type", func(input *Input) {
+		input.MountPoints = []management.MountPoint{{Type: "nfs", ContainerPath: "/nfs"}}
+	})
+
+	addError("unsupported network mode", func(input *Input) {
+		input.NetworkMode = "overlay"
+	})
+
+	addError("missing seccomp profile", func(input *Input) {
+		input.SecurityOptions = management.SecurityOptions{SeccompProfile: filepath.Join(dir, "missing.json")}
+	})
+
+	return cases
+}
+
+// normalize orders lists which order backends do not keep.
+func normalize(container *Container) {
+	sort.Slice(container.Mounts, func(i, j int) bool { return container.Mounts[i].Target < container.Mounts[j].Target })
+	sort.Slice(container.PortBindings, func(i, j int) bool {
+		return container.PortBindings[i].ContainerPort < container.PortBindings[j].ContainerPort
+	})
+	sort.Slice(container.Ulimits, func(i, j int) bool { return container.Ulimits[i].Name < container.Ulimits[j].Name })
+	if len(container.Mounts) == 0 {
+		container.Mounts = nil
+	}
+	if len(container.PortBindings) == 0 {
+		container.PortBindings = nil
+	}
+	if len(container.Ulimits) == 0 {
+		container.Ulimits = nil
+	}
+	if len(container.CapAdd) == 0 {
+		container.CapAdd = nil
+	}
+	if len(container.CapDrop) == 0 {
+		container.CapDrop = nil
+	}
+	if container.Env == nil {
+		container.Env = map[string]string{}
+	}
+	if container.Labels == nil {
+		container.Labels = map[string]string{}
+	}
+}
@@ -5,15 +5,18 @@ import (
 
 	"dev-runner/pkg/conainer/management"
 	"dev-runner/pkg/conainer/management/docker"
+	"dev-runner/pkg/conainer/management/fake"
 	"dev-runner/pkg/conainer/management/podman"
 )
 
-func CreateContainerManager(name string) (manager management.ContainerManager, err error) {
+func CreateContainerManager(name string, connection management.ConnectionOptions) (manager management.ContainerManager, err error) {
 	switch name {
 	case "docker":
-		return docker.NewDockerManager(), nil
+		return docker.NewDockerManager(connection), nil
 	case "podman":
-		return podman.NewPodmanManager(), nil
+		return podman.NewPodmanManager(connection), nil
+	case "fake":
+		return fake.NewFakeManager(), nil
 	}
-	return nil, fmt.Errorf("conatainer manager '%s' is not supported")
+	return nil, fmt.Errorf("conatainer manager '%s' is not supported", name)
 }
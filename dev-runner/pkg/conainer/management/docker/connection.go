@@ -0,0 +1,210 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/cli/cli/connhelper"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/tlsconfig"
+
+	"dev-runner/pkg/conainer/management"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+// defaultContextName is the docker context which uses environment and built-in defaults.
+const defaultContextName = "default"
+
+// endpoint is the docker endpoint of a docker context or a host set explicitly.
+type endpoint struct {
+	Host          string
+	SkipTLSVerify bool
+	// TLSDir holds ca.pem, cert.pem and key.pem of context, empty if context has no TLS data.
+	TLSDir string
+}
+
+type contextMeta struct {
+	Name      string
+	Endpoints map[string]endpoint
+}
+
+type configFile struct {
+	CurrentContext string `json:"currentContext"`
+}
+
+// getClientOpts returns options of docker client for the endpoint resolved in order:
+// explicit URL, named context, DOCKER_HOST, DOCKER_CONTEXT and current context from config.json.
+func getClientOpts(connection management.ConnectionOptions) (opts []client.Opt, err error) {
+	opts = []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+
+	var endpoint_ endpoint
+	endpoint_, err = resolveEndpoint(connection)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint_.Host == "" {
+		return opts, nil
+	}
+
+	var endpointOpts []client.Opt
+	endpointOpts, err = getEndpointOpts(endpoint_)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, endpointOpts...), nil
+}
+
+func resolveEndpoint(connection management.ConnectionOptions) (endpoint_ endpoint, err error) {
+	if connection.Url != "" {
+		return endpoint{Host: connection.Url}, nil
+	}
+	if connection.Name != "" {
+		return loadContextEndpoint(connection.Name)
+	}
+	if os.Getenv(client.EnvOverrideHost) != "" {
+		return endpoint{}, nil
+	}
+	if contextName := os.Getenv("DOCKER_CONTEXT"); contextName != "" {
+		return loadContextEndpoint(contextName)
+	}
+
+	var contextName string
+	contextName, err = loadCurrentContextName()
+	if err != nil {
+		return endpoint{}, err
+	}
+	return loadContextEndpoint(contextName)
+}
+
+func getEndpointOpts(endpoint_ endpoint) (opts []client.Opt, err error) {
+	var host *url.URL
+	host, err = url.Parse(endpoint_.Host)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect docker host '%s': %w", endpoint_.Host, err)
+	}
+
+	if host.Scheme == "ssh" {
+		var helper *connhelper.ConnectionHelper
+		helper, err = connhelper.GetConnectionHelper(endpoint_.Host)
+		if err != nil {
+			return nil, fmt.Errorf("cannot connect docker host '%s': %w", endpoint_.Host, err)
+		}
+		return []client.Opt{
+			client.WithHTTPClient(&http.Client{Transport: &http.Transport{DialContext: helper.Dialer}}),
+			client.WithHost(helper.Host),
+			client.WithDialContext(helper.Dialer),
+		}, nil
+	}
+
+	if endpoint_.TLSDir != "" || endpoint_.SkipTLSVerify {
+		options := tlsconfig.Options{InsecureSkipVerify: endpoint_.SkipTLSVerify}
+		if endpoint_.TLSDir != "" {
+			options.CAFile = filepath.Join(endpoint_.TLSDir, "ca.pem")
+			options.CertFile = filepath.Join(endpoint_.TLSDir, "cert.pem")
+			options.KeyFile = filepath.Join(endpoint_.TLSDir, "key.pem")
+		}
+
+		var tlsConfig_ *tls.Config
+		tlsConfig_, err = tlsconfig.Client(options)
+		if err != nil {
+			return nil, fmt.Errorf("cannot configure TLS of docker host '%s': %w", endpoint_.Host, err)
+		}
+		opts = append(opts, client.WithHTTPClient(&http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig_}}))
+	}
+	return append(opts, client.WithHost(endpoint_.Host)), nil
+}
+
+func getConfigDir() (dir string, err error) {
+	if dir = os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir, nil
+	}
+
+	var homeDir string
+	homeDir, err = os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot get user home dir: %w", err)
+	}
+	return filepath.Join(homeDir, ".docker"), nil
+}
+
+func loadCurrentContextName() (name string, err error) {
+	var configDir string
+	configDir, err = getConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(configDir, "config.json")
+	var data []byte
+	data, err = os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return defaultContextName, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot read docker config '%s': %w", path, err)
+	}
+
+	var config configFile
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse docker config '%s': %w", path, err)
+	}
+	if config.CurrentContext == "" {
+		return defaultContextName, nil
+	}
+	return config.CurrentContext, nil
+}
+
+// loadContextEndpoint reads docker endpoint from docker CLI context store,
+// default context gives empty endpoint to use environment.
+func loadContextEndpoint(name string) (endpoint_ endpoint, err error) {
+	if name == defaultContextName {
+		return endpoint{}, nil
+	}
+
+	var configDir string
+	configDir, err = getConfigDir()
+	if err != nil {
+		return endpoint{}, err
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	contextId := hex.EncodeToString(sum[:])
+
+	path := filepath.Join(configDir, "contexts", "meta", contextId, "meta.json")
+	var data []byte
+	data, err = os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return endpoint{}, fmt.Errorf("docker context '%s' not found", name)
+	}
+	if err != nil {
+		return endpoint{}, fmt.Errorf("cannot read docker context '%s': %w", name, err)
+	}
+
+	var meta contextMeta
+	err = json.Unmarshal(data, &meta)
+	if err != nil {
+		return endpoint{}, fmt.Errorf("cannot parse docker context '%s': %w", name, err)
+	}
+
+	endpoint_, ok := meta.Endpoints["docker"]
+	if !ok || endpoint_.Host == "" {
+		return endpoint{}, fmt.Errorf("docker context '%s' has no docker endpoint", name)
+	}
+
+	tlsDir := filepath.Join(configDir, "contexts", "tls", contextId, "docker")
+	if fp.IsDir(tlsDir) {
+		endpoint_.TLSDir = tlsDir
+	}
+	return endpoint_, nil
+}
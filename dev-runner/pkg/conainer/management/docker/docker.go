@@ -2,35 +2,51 @@ package docker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 
+	"dev-runner/pkg/archive"
 	"dev-runner/pkg/conainer/management"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
-	"github.com/docker/docker/api/types/strslice"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
+	"github.com/moby/term"
 
 	"github.com/docker/docker/client"
 )
 
 type dockerManager struct {
-	con *client.Client
+	connection management.ConnectionOptions
+	con        *client.Client
 }
 
-func NewDockerManager() management.ContainerManager {
-	return &dockerManager{}
+func NewDockerManager(connection management.ConnectionOptions) management.ContainerManager {
+	return &dockerManager{connection: connection}
 }
 
 func (m *dockerManager) Init(_ context.Context) (err error) {
+	var opts []client.Opt
+	opts, err = getClientOpts(m.connection)
+	if err != nil {
+		return fmt.Errorf("cannot resolve docker host: %w", err)
+	}
+
 	var con *client.Client
-	con, err = client.NewClientWithOpts(client.FromEnv)
+	con, err = client.NewClientWithOpts(opts...)
 	if err != nil {
 		return fmt.Errorf("cannot create client: %w", err)
 	}
@@ -42,12 +58,41 @@ func (m *dockerManager) Init(_ context.Context) (err error) {
 func (m *dockerManager) LoadImage(
 	ctx context.Context,
 	r io.Reader,
-) (err error) {
-	_, err = m.con.ImageLoad(ctx, r, true)
+) (imageNames []string, err error) {
+	var resp_ image.LoadResponse
+	resp_, err = m.con.ImageLoad(ctx, r, true)
 	if err != nil {
-		return fmt.Errorf("cannot load image: %w", err)
+		return nil, fmt.Errorf("cannot load image: %w", err)
 	}
-	return nil
+	defer func() {
+		_ = resp_.Body.Close()
+	}()
+
+	decoder := json.NewDecoder(resp_.Body)
+	for {
+		var msg_ jsonmessage.JSONMessage
+		err = decoder.Decode(&msg_)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read image load response: %w", err)
+		}
+		if msg_.Error != nil {
+			return nil, fmt.Errorf("cannot load image: %w", msg_.Error)
+		}
+
+		for _, line := range strings.Split(msg_.Stream, "\n") {
+			if name, ok := strings.CutPrefix(line, "Loaded image: "); ok {
+				imageNames = append(imageNames, strings.TrimSpace(name))
+				continue
+			}
+			if id, ok := strings.CutPrefix(line, "Loaded image ID: "); ok {
+				imageNames = append(imageNames, strings.TrimSpace(id))
+			}
+		}
+	}
+	return imageNames, nil
 }
 
 func (m *dockerManager) GetImageLabels(
@@ -57,11 +102,11 @@ func (m *dockerManager) GetImageLabels(
 	var inspect types.ImageInspect
 	inspect, _, err = m.con.ImageInspectWithRaw(ctx, imageName)
 	if err != nil {
-		return nil, fmt.Errorf("cannot inspect image '%s': %w", err)
+		return nil, fmt.Errorf("cannot inspect image '%s': %w", imageName, err)
 	}
 
 	if len(inspect.Config.Labels) > 0 {
-		labels = make([]management.Label, len(inspect.Config.Labels))
+		labels = make([]management.Label, 0, len(inspect.Config.Labels))
 		for name, value := range inspect.Config.Labels {
 			labels = append(labels, management.Label{Name: name, Value: value})
 		}
@@ -69,6 +114,130 @@ func (m *dockerManager) GetImageLabels(
 	return labels, nil
 }
 
+func (m *dockerManager) ListImages(
+	ctx context.Context,
+	labelName string,
+) (images []management.ImageSummary, err error) {
+	options := image.ListOptions{}
+	if labelName != "" {
+		options.Filters = filters.NewArgs(filters.Arg("label", labelName))
+	}
+
+	var summaries_ []image.Summary
+	summaries_, err = m.con.ImageList(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list images: %w", err)
+	}
+
+	for _, item := range summaries_ {
+		images = append(images, newImageSummary(item.ID, item.RepoTags, item.Created, item.Size, item.Labels))
+	}
+	return images, nil
+}
+
+// buildDockerfileName is the name Dockerfile is added to build context tarball with.
+const buildDockerfileName = ".dev-runner.Dockerfile"
+
+func (m *dockerManager) BuildImage(
+	ctx context.Context,
+	options management.BuildOptions,
+	progress io.Writer,
+) (err error) {
+	// The daemon takes Dockerfile from context tarball, so it is added there
+	// as it may be outside of context dir.
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(archive.TarDirectory(
+			pw,
+			options.ContextDir,
+			map[string]string{buildDockerfileName: options.Dockerfile},
+		))
+	}()
+	defer func() { _ = pr.Close() }()
+
+	buildArgs_ := make(map[string]*string, len(options.BuildArgs))
+	for _, item := range options.BuildArgs {
+		value := item.Value
+		buildArgs_[item.Name] = &value
+	}
+
+	labels_ := make(map[string]string, len(options.Labels))
+	for _, item := range options.Labels {
+		labels_[item.Name] = item.Value
+	}
+
+	var resp_ types.ImageBuildResponse
+	resp_, err = m.con.ImageBuild(ctx, pr, types.ImageBuildOptions{
+		Tags:        options.Tags,
+		Dockerfile:  buildDockerfileName,
+		BuildArgs:   buildArgs_,
+		Labels:      labels_,
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot build image '%s': %w", options.Tags[0], err)
+	}
+	defer func() {
+		_ = resp_.Body.Close()
+	}()
+
+	fd, isTerminal := term.GetFdInfo(progress)
+	err = jsonmessage.DisplayJSONMessagesStream(resp_.Body, progress, fd, isTerminal, nil)
+	if err != nil {
+		return fmt.Errorf("cannot build image '%s': %w", options.Tags[0], err)
+	}
+	return nil
+}
+
+func (m *dockerManager) IsImageExists(
+	ctx context.Context,
+	imageName string,
+) (exists bool, err error) {
+	_, _, err = m.con.ImageInspectWithRaw(ctx, imageName)
+	if client.IsErrNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot inspect image '%s': %w", imageName, err)
+	}
+	return true, nil
+}
+
+func (m *dockerManager) PullImage(
+	ctx context.Context,
+	imageName string,
+	auth management.RegistryAuth,
+	progress io.Writer,
+) (err error) {
+	var registryAuth_ string
+	registryAuth_, err = registry.EncodeAuthConfig(registry.AuthConfig{
+		Username:      auth.Username,
+		Password:      auth.Password,
+		ServerAddress: auth.ServerAddress,
+		IdentityToken: auth.IdentityToken,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot encode registry credentials: %w", err)
+	}
+
+	var resp_ io.ReadCloser
+	resp_, err = m.con.ImagePull(ctx, imageName, image.PullOptions{RegistryAuth: registryAuth_})
+	if err != nil {
+		return fmt.Errorf("cannot pull image '%s': %w", imageName, err)
+	}
+	defer func() {
+		_ = resp_.Close()
+	}()
+
+	fd, isTerminal := term.GetFdInfo(progress)
+	err = jsonmessage.DisplayJSONMessagesStream(resp_, progress, fd, isTerminal, nil)
+	if err != nil {
+		return fmt.Errorf("cannot pull image '%s': %w", imageName, err)
+	}
+	return nil
+}
+
 func (m *dockerManager) RunContainer(
 	ctx context.Context,
 	imageName string,
@@ -76,43 +245,27 @@ func (m *dockerManager) RunContainer(
 	mountPoints []management.MountPoint,
 	environmentVariables []management.EnvironmentVariable,
 	portBindings []management.PortBinding,
+	labels []management.Label,
 	networkMode management.NetworkMode,
+	userMapping management.UserMapping,
+	resourceLimits management.ResourceLimits,
+	securityOptions management.SecurityOptions,
+	interactive bool,
 ) (containerId string, err error) {
-	var environmentVariables_ []string
-	for _, item := range environmentVariables {
-		environmentVariables_ = append(
-			environmentVariables_,
-			fmt.Sprintf("%s=%s", os.ExpandEnv(item.Name), os.ExpandEnv(item.Value)),
-		)
-	}
-
-	var mountPoints_ []mount.Mount
-	for _, item := range mountPoints {
-		mountPoints_ = append(
-			mountPoints_,
-			mount.Mount{
-				Type:     mount.TypeBind,
-				Source:   os.ExpandEnv(item.HostPath),
-				Target:   os.ExpandEnv(item.ContainerPath),
-				ReadOnly: item.ReadOnly,
-			},
-		)
-	}
-
-	var portBindings_ nat.PortMap
-	if len(portBindings) > 0 {
-		portBindings_ = make(nat.PortMap, len(portBindings))
-		for _, item := range portBindings {
-			containerPort := nat.Port(strconv.Itoa(item.ContainerPort))
-			hostPort := strconv.Itoa(item.HostPort)
-			portBindings_[containerPort] = []nat.PortBinding{{
-				HostPort: hostPort,
-			}}
-		}
-	}
-
-	var networkMode_ container.NetworkMode
-	networkMode_, err = getNetworkMode(networkMode)
+	var config_ *container.Config
+	var hostConfig_ *container.HostConfig
+	config_, hostConfig_, err = buildContainerConfigs(
+		imageName,
+		mountPoints,
+		environmentVariables,
+		portBindings,
+		labels,
+		networkMode,
+		userMapping,
+		resourceLimits,
+		securityOptions,
+		interactive,
+	)
 	if err != nil {
 		return "", err
 	}
@@ -120,22 +273,8 @@ func (m *dockerManager) RunContainer(
 	var containerResp_ container.CreateResponse
 	containerResp_, err = m.con.ContainerCreate(
 		ctx,
-		&container.Config{
-			Image: imageName,
-			Env:   environmentVariables_,
-		},
-		&container.HostConfig{
-			Mounts:       mountPoints_,
-			NetworkMode:  networkMode_,
-			PortBindings: portBindings_,
-			CapAdd: strslice.StrSlice{
-				"CAP_AUDIT_WRITE",
-				"SYS_PTRACE",
-				"NET_RAW",
-				"NET_ADMIN",
-			},
-			SecurityOpt: []string{"seccomp=unconfined"},
-		},
+		config_,
+		hostConfig_,
 		nil,
 		nil,
 		containerName,
@@ -146,6 +285,14 @@ func (m *dockerManager) RunContainer(
 
 	containerId = containerResp_.ID
 
+	if interactive {
+		err = m.runAttached(ctx, containerId)
+		if err != nil {
+			return "", fmt.Errorf("cannot run container '%s' from image '%s' attached: %w", containerId, imageName, err)
+		}
+		return containerId, nil
+	}
+
 	err = m.con.ContainerStart(ctx, containerId, container.StartOptions{})
 	if err != nil {
 		return "", fmt.Errorf("cannot start container '%s' from image '%s': %w", containerId, imageName, err)
@@ -154,28 +301,253 @@ func (m *dockerManager) RunContainer(
 	return containerId, nil
 }
 
+// runAttached starts container with stdio attached and waits until it exits.
+func (m *dockerManager) runAttached(ctx context.Context, containerId string) (err error) {
+	var attachResp_ types.HijackedResponse
+	attachResp_, err = m.con.ContainerAttach(
+		ctx,
+		containerId,
+		container.AttachOptions{
+			Stream: true,
+			Stdin:  true,
+			Stdout: true,
+			Stderr: true,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("cannot attach to container: %w", err)
+	}
+	defer attachResp_.Close()
+
+	waitCh, errCh := m.con.ContainerWait(ctx, containerId, container.WaitConditionNextExit)
+
+	err = m.con.ContainerStart(ctx, containerId, container.StartOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot start container: %w", err)
+	}
+
+	go func() {
+		_, _ = io.Copy(attachResp_.Conn, os.Stdin)
+		_ = attachResp_.CloseWrite()
+	}()
+	outputDone := make(chan struct{})
+	go func() {
+		defer close(outputDone)
+		_, _ = io.Copy(os.Stdout, attachResp_.Reader)
+	}()
+
+	select {
+	case resp_ := <-waitCh:
+		// The output stream ends once the container exits, its tail must be printed before the connection is closed.
+		select {
+		case <-outputDone:
+		case <-ctx.Done():
+		}
+		if resp_.Error != nil {
+			return fmt.Errorf("container wait failed: %s", resp_.Error.Message)
+		}
+		if resp_.StatusCode != 0 {
+			return fmt.Errorf("container exited with code %d", resp_.StatusCode)
+		}
+	case err = <-errCh:
+		return fmt.Errorf("container wait failed: %w", err)
+	}
+	return nil
+}
+
+func (m *dockerManager) ListContainers(
+	ctx context.Context,
+) (containers []management.ContainerInfo, err error) {
+	var list_ []types.Container
+	list_, err = m.con.ContainerList(
+		ctx,
+		container.ListOptions{
+			All:     true,
+			Filters: filters.NewArgs(filters.Arg("label", management.ManagedLabel)),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list containers: %w", err)
+	}
+
+	for _, item := range list_ {
+		var inspect_ types.ContainerJSON
+		inspect_, err = m.con.ContainerInspect(ctx, item.ID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot inspect container '%s': %w", item.ID, err)
+		}
+
+		var startedAt time.Time
+		if inspect_.State != nil && inspect_.State.Running {
+			startedAt, _ = time.Parse(time.RFC3339Nano, inspect_.State.StartedAt)
+		}
+
+		var labels []management.Label
+		for name, value := range item.Labels {
+			labels = append(labels, management.Label{Name: name, Value: value})
+		}
+
+		containers = append(
+			containers,
+			management.ContainerInfo{
+				Id:        item.ID,
+				Name:      strings.TrimPrefix(inspect_.Name, "/"),
+				Image:     item.Image,
+				State:     item.State,
+				StartedAt: startedAt,
+				Labels:    labels,
+			},
+		)
+	}
+	return containers, nil
+}
+
+func (m *dockerManager) IsContainerExists(
+	ctx context.Context,
+	containerName string,
+) (exists bool, err error) {
+	_, err = m.con.ContainerInspect(ctx, containerName)
+	if client.IsErrNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot inspect container '%s': %w", containerName, err)
+	}
+	return true, nil
+}
+
+func (m *dockerManager) StartContainer(
+	ctx context.Context,
+	containerName string,
+) (err error) {
+	err = m.con.ContainerStart(ctx, containerName, container.StartOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot start container '%s': %w", containerName, err)
+	}
+	return nil
+}
+
 func (m *dockerManager) StopContainer(
 	ctx context.Context,
 	containerName string,
+	timeout int,
 ) (err error) {
-	err = m.con.ContainerStop(ctx, containerName, container.StopOptions{})
+	var stopOptions_ container.StopOptions
+	if timeout >= 0 {
+		stopOptions_.Timeout = &timeout
+	}
+
+	err = m.con.ContainerStop(ctx, containerName, stopOptions_)
 	if err != nil {
 		return fmt.Errorf("cannot stop container '%s': %w", containerName, err)
 	}
+	return nil
+}
 
-	err = m.con.ContainerRemove(ctx, containerName, container.RemoveOptions{RemoveVolumes: true})
+func (m *dockerManager) RemoveContainer(
+	ctx context.Context,
+	containerName string,
+	force bool,
+) (err error) {
+	err = m.con.ContainerRemove(ctx, containerName, container.RemoveOptions{RemoveVolumes: true, Force: force})
 	if err != nil {
 		return fmt.Errorf("cannot remove container '%s': %w", containerName, err)
 	}
-
 	return nil
 }
 
+func (m *dockerManager) ExecInContainer(
+	ctx context.Context,
+	containerName string,
+	options management.ExecOptions,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+) (exitCode int, err error) {
+	var environmentVariables_ []string
+	for _, item := range options.EnvironmentVariables {
+		environmentVariables_ = append(environmentVariables_, fmt.Sprintf("%s=%s", item.Name, item.Value))
+	}
+
+	var execResp_ types.IDResponse
+	execResp_, err = m.con.ContainerExecCreate(
+		ctx,
+		containerName,
+		container.ExecOptions{
+			User:         options.User,
+			Tty:          options.Tty,
+			AttachStdin:  options.AttachStdin,
+			AttachStdout: true,
+			AttachStderr: true,
+			Env:          environmentVariables_,
+			WorkingDir:   options.WorkDir,
+			Cmd:          options.Cmd,
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("cannot create exec in container '%s': %w", containerName, err)
+	}
+
+	var attachResp_ types.HijackedResponse
+	attachResp_, err = m.con.ContainerExecAttach(ctx, execResp_.ID, container.ExecAttachOptions{Tty: options.Tty})
+	if err != nil {
+		return 0, fmt.Errorf("cannot attach to exec in container '%s': %w", containerName, err)
+	}
+	defer attachResp_.Close()
+
+	if options.AttachStdin {
+		go func() {
+			_, _ = io.Copy(attachResp_.Conn, stdin)
+			_ = attachResp_.CloseWrite()
+		}()
+	}
+
+	// Reading the hijacked connection does not watch the context, so the copy runs aside
+	// and the connection is closed to stop it once the context is done.
+	copyErr := make(chan error, 1)
+	go func() {
+		var copyErr_ error
+		// Without TTY stdout and stderr are multiplexed into single stream.
+		if options.Tty {
+			_, copyErr_ = io.Copy(stdout, attachResp_.Reader)
+		} else {
+			_, copyErr_ = stdcopy.StdCopy(stdout, stderr, attachResp_.Reader)
+		}
+		copyErr <- copyErr_
+	}()
+
+	select {
+	case err = <-copyErr:
+	case <-ctx.Done():
+		attachResp_.Close()
+		<-copyErr
+		return 0, fmt.Errorf("cannot wait exec in container '%s': %w", containerName, ctx.Err())
+	}
+	if err != nil {
+		return 0, fmt.Errorf("cannot read exec output in container '%s': %w", containerName, err)
+	}
+
+	var inspect_ container.ExecInspect
+	inspect_, err = m.con.ContainerExecInspect(ctx, execResp_.ID)
+	if err != nil {
+		return 0, fmt.Errorf("cannot inspect exec in container '%s': %w", containerName, err)
+	}
+	return inspect_.ExitCode, nil
+}
+
 func (m *dockerManager) PrintContainerLogs(
 	ctx context.Context,
 	containerName string,
-	w io.Writer,
+	options management.LogsOptions,
+	stdout io.Writer,
+	stderr io.Writer,
 ) (err error) {
+	var inspect_ types.ContainerJSON
+	inspect_, err = m.con.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("cannot inspect container '%s': %w", containerName, err)
+	}
+
 	var reader_ io.ReadCloser
 	reader_, err = m.con.ContainerLogs(
 		ctx,
@@ -183,7 +555,11 @@ func (m *dockerManager) PrintContainerLogs(
 		container.LogsOptions{
 			ShowStderr: true,
 			ShowStdout: true,
-			Timestamps: true,
+			Since:      options.Since,
+			Until:      options.Until,
+			Timestamps: options.Timestamps,
+			Follow:     options.Follow,
+			Tail:       options.Tail,
 		},
 	)
 	if err != nil {
@@ -193,14 +569,162 @@ func (m *dockerManager) PrintContainerLogs(
 		_ = reader_.Close()
 	}()
 
-	_, err = io.Copy(w, reader_)
-	if err != nil {
+	// Logs of container with TTY are raw, otherwise stdout and stderr are multiplexed.
+	if inspect_.Config != nil && inspect_.Config.Tty {
+		_, err = io.Copy(stdout, reader_)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, reader_)
+	}
+	if err != nil && ctx.Err() == nil {
 		return fmt.Errorf("cannot print container logs from '%s': %w", containerName, err)
 	}
 
 	return nil
 }
 
+// buildContainerConfigs translates RunContainer arguments into docker container configs.
+func buildContainerConfigs(
+	imageName string,
+	mountPoints []management.MountPoint,
+	environmentVariables []management.EnvironmentVariable,
+	portBindings []management.PortBinding,
+	labels []management.Label,
+	networkMode management.NetworkMode,
+	userMapping management.UserMapping,
+	resourceLimits management.ResourceLimits,
+	securityOptions management.SecurityOptions,
+	interactive bool,
+) (config *container.Config, hostConfig *container.HostConfig, err error) {
+	var environmentVariables_ []string
+	for _, item := range environmentVariables {
+		environmentVariables_ = append(
+			environmentVariables_,
+			fmt.Sprintf("%s=%s", os.ExpandEnv(item.Name), os.ExpandEnv(item.Value)),
+		)
+	}
+
+	// Dev images entrypoint changes the container user ids to the host ones.
+	if userMapping.Enabled {
+		environmentVariables_ = append(
+			environmentVariables_,
+			fmt.Sprintf("%s=%d", management.UserUidEnvironmentVariable, userMapping.HostUid),
+			fmt.Sprintf("%s=%d", management.UserGidEnvironmentVariable, userMapping.HostGid),
+		)
+	}
+
+	var mountPoints_ []mount.Mount
+	var binds_ []string
+	for _, item := range mountPoints {
+		switch item.GetType() {
+		case management.MountBind:
+			if item.SELinuxRelabel != "" {
+				// Mounts API has no SELinux relabel, so only binds support it.
+				binds_ = append(binds_, getBind(item))
+				continue
+			}
+			mountPoints_ = append(
+				mountPoints_,
+				mount.Mount{
+					Type:     mount.TypeBind,
+					Source:   os.ExpandEnv(item.HostPath),
+					Target:   os.ExpandEnv(item.ContainerPath),
+					ReadOnly: item.ReadOnly,
+				},
+			)
+		case management.MountVolume:
+			mountPoints_ = append(
+				mountPoints_,
+				mount.Mount{
+					Type:     mount.TypeVolume,
+					Source:   item.VolumeName,
+					Target:   os.ExpandEnv(item.ContainerPath),
+					ReadOnly: item.ReadOnly,
+				},
+			)
+		case management.MountTmpfs:
+			mountPoints_ = append(
+				mountPoints_,
+				mount.Mount{
+					Type:     mount.TypeTmpfs,
+					Target:   os.ExpandEnv(item.ContainerPath),
+					ReadOnly: item.ReadOnly,
+					TmpfsOptions: &mount.TmpfsOptions{
+						SizeBytes: item.TmpfsSize,
+						Mode:      item.TmpfsMode,
+					},
+				},
+			)
+		default:
+			return nil, nil, fmt.Errorf("unsupported mount type '%s'", item.Type)
+		}
+	}
+
+	var exposedPorts_ nat.PortSet
+	var portBindings_ nat.PortMap
+	if len(portBindings) > 0 {
+		exposedPorts_ = make(nat.PortSet, len(portBindings))
+		portBindings_ = make(nat.PortMap, len(portBindings))
+		for _, item := range portBindings {
+			var containerPort nat.Port
+			containerPort, err = nat.NewPort("tcp", strconv.Itoa(item.ContainerPort))
+			if err != nil {
+				return nil, nil, fmt.Errorf("incorrect container port '%d': %w", item.ContainerPort, err)
+			}
+			hostPort := strconv.Itoa(item.HostPort)
+			exposedPorts_[containerPort] = struct{}{}
+			portBindings_[containerPort] = append(
+				portBindings_[containerPort],
+				nat.PortBinding{
+					HostIP:   item.HostAddress,
+					HostPort: hostPort,
+				},
+			)
+		}
+	}
+
+	labels_ := map[string]string{management.ManagedLabel: "true"}
+	for _, item := range labels {
+		labels_[item.Name] = item.Value
+	}
+
+	var networkMode_ container.NetworkMode
+	networkMode_, err = getNetworkMode(networkMode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var securityOpt_ []string
+	securityOpt_, err = getSecurityOpt(securityOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config = &container.Config{
+		Image:        imageName,
+		Env:          environmentVariables_,
+		Labels:       labels_,
+		ExposedPorts: exposedPorts_,
+		Tty:          interactive,
+		OpenStdin:    interactive,
+		StdinOnce:    interactive,
+		AttachStdin:  interactive,
+		AttachStdout: interactive,
+		AttachStderr: interactive,
+	}
+	hostConfig = &container.HostConfig{
+		Mounts:       mountPoints_,
+		Binds:        binds_,
+		NetworkMode:  networkMode_,
+		PortBindings: portBindings_,
+		CapAdd:       securityOptions.CapAdd,
+		CapDrop:      securityOptions.CapDrop,
+		SecurityOpt:  securityOpt_,
+		ShmSize:      resourceLimits.ShmSize,
+		Resources:    getResources(resourceLimits),
+	}
+	return config, hostConfig, nil
+}
+
 func getNetworkMode(mode management.NetworkMode) (networkMode container.NetworkMode, err error) {
 	switch mode {
 	case management.NetworkBridge:
@@ -211,3 +735,64 @@ func getNetworkMode(mode management.NetworkMode) (networkMode container.NetworkM
 		return network.NetworkDefault, fmt.Errorf("network mode '%v' is not supported", mode)
 	}
 }
+
+func getResources(limits management.ResourceLimits) (resources container.Resources) {
+	resources.NanoCPUs = int64(limits.Cpus * 1e9)
+	resources.Memory = limits.Memory
+	resources.MemorySwap = limits.MemorySwap
+	if limits.PidsLimit != 0 {
+		resources.PidsLimit = &limits.PidsLimit
+	}
+	for _, item := range limits.Ulimits {
+		resources.Ulimits = append(
+			resources.Ulimits,
+			&container.Ulimit{
+				Name: item.Name,
+				Soft: item.Soft,
+				Hard: item.Hard,
+			},
+		)
+	}
+	return resources
+}
+
+func getBind(mountPoint management.MountPoint) string {
+	options := mountPoint.SELinuxRelabel
+	if mountPoint.ReadOnly {
+		options = "ro," + options
+	}
+	return fmt.Sprintf("%s:%s:%s", os.ExpandEnv(mountPoint.HostPath), os.ExpandEnv(mountPoint.ContainerPath), options)
+}
+
+func getSecurityOpt(options management.SecurityOptions) (securityOpt []string, err error) {
+	switch options.SeccompProfile {
+	case "":
+	case management.SeccompUnconfined:
+		securityOpt = append(securityOpt, "seccomp="+management.SeccompUnconfined)
+	default:
+		// Docker daemon expects the profile content instead of the path.
+		var profile []byte
+		profile, err = os.ReadFile(options.SeccompProfile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read seccomp profile '%s': %w", options.SeccompProfile, err)
+		}
+		securityOpt = append(securityOpt, "seccomp="+string(profile))
+	}
+	if options.NoNewPrivileges {
+		securityOpt = append(securityOpt, "no-new-privileges:true")
+	}
+	return securityOpt, nil
+}
+
+func newImageSummary(id string, tags []string, created int64, size int64, labels map[string]string) management.ImageSummary {
+	summary := management.ImageSummary{
+		Id:      id,
+		Tags:    tags,
+		Created: time.Unix(created, 0),
+		Size:    size,
+	}
+	for name, value := range labels {
+		summary.Labels = append(summary.Labels, management.Label{Name: name, Value: value})
+	}
+	return summary
+}
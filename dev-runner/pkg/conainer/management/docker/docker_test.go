@@ -0,0 +1,131 @@
+package docker
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/conainer/management/conformance"
+)
+
+func TestBuildContainerConfigsConformance(t *testing.T) {
+	conformance.Run(t, func(input conformance.Input) (c conformance.Container, err error) {
+		var config *container.Config
+		var hostConfig *container.HostConfig
+		config, hostConfig, err = buildContainerConfigs(
+			input.ImageName,
+			input.MountPoints,
+			input.EnvironmentVariables,
+			input.PortBindings,
+			input.Labels,
+			input.NetworkMode,
+			input.UserMapping,
+			input.ResourceLimits,
+			input.SecurityOptions,
+			input.Interactive,
+		)
+		if err != nil {
+			return conformance.Container{}, err
+		}
+		return toConformanceContainer(t, config, hostConfig), nil
+	})
+}
+
+func toConformanceContainer(t *testing.T, config *container.Config, hostConfig *container.HostConfig) (c conformance.Container) {
+	c = conformance.Container{
+		Image:       config.Image,
+		Network:     management.NetworkMode(hostConfig.NetworkMode),
+		Env:         map[string]string{},
+		Labels:      config.Labels,
+		CapAdd:      hostConfig.CapAdd,
+		CapDrop:     hostConfig.CapDrop,
+		Cpus:        float64(hostConfig.NanoCPUs) / 1e9,
+		Memory:      hostConfig.Memory,
+		MemorySwap:  hostConfig.MemorySwap,
+		ShmSize:     hostConfig.ShmSize,
+		Interactive: config.Tty && config.OpenStdin,
+	}
+
+	for _, item := range config.Env {
+		name, value, _ := strings.Cut(item, "=")
+		c.Env[name] = value
+	}
+
+	for _, item := range hostConfig.Mounts {
+		m := conformance.Mount{
+			Type:     management.MountType(item.Type),
+			Source:   item.Source,
+			Target:   item.Target,
+			ReadOnly: item.ReadOnly,
+		}
+		if item.Type == mount.TypeBind {
+			m.Recursive = item.BindOptions == nil || !item.BindOptions.NonRecursive
+		}
+		if item.TmpfsOptions != nil {
+			m.TmpfsSize = item.TmpfsOptions.SizeBytes
+			m.TmpfsMode = item.TmpfsOptions.Mode
+		}
+		c.Mounts = append(c.Mounts, m)
+	}
+	for _, item := range hostConfig.Binds {
+		parts := strings.Split(item, ":")
+		if len(parts) < 2 {
+			t.Fatalf("invalid bind '%s'", item)
+		}
+		// Binds are recursive unless 'norbind' is set.
+		m := conformance.Mount{Type: management.MountBind, Source: parts[0], Target: parts[1], Recursive: true}
+		if len(parts) > 2 {
+			for _, option := range strings.Split(parts[2], ",") {
+				switch option {
+				case "ro":
+					m.ReadOnly = true
+				case "z", "Z":
+					m.SELinuxRelabel = option
+				case "norbind":
+					m.Recursive = false
+				}
+			}
+		}
+		c.Mounts = append(c.Mounts, m)
+	}
+
+	for port, bindings := range hostConfig.PortBindings {
+		if _, ok := config.ExposedPorts[port]; !ok {
+			t.Errorf("port '%s' is bound but not exposed", port)
+		}
+		for _, binding := range bindings {
+			hostPort, err := strconv.Atoi(binding.HostPort)
+			if err != nil {
+				t.Fatalf("invalid host port '%s'", binding.HostPort)
+			}
+			c.PortBindings = append(c.PortBindings, management.PortBinding{
+				ContainerPort: port.Int(),
+				HostPort:      hostPort,
+				HostAddress:   binding.HostIP,
+			})
+		}
+	}
+
+	for _, option := range hostConfig.SecurityOpt {
+		switch {
+		case strings.HasPrefix(option, "seccomp="):
+			c.SeccompProfile = strings.TrimPrefix(option, "seccomp=")
+		case option == "no-new-privileges:true":
+			c.NoNewPrivileges = true
+		default:
+			t.Errorf("unexpected security option '%s'", option)
+		}
+	}
+
+	if hostConfig.PidsLimit != nil {
+		c.PidsLimit = *hostConfig.PidsLimit
+	}
+	for _, item := range hostConfig.Ulimits {
+		c.Ulimits = append(c.Ulimits, management.Ulimit{Name: item.Name, Soft: item.Soft, Hard: item.Hard})
+	}
+	return c
+}
@@ -0,0 +1,489 @@
+package fake
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"dev-runner/pkg/conainer/management"
+)
+
+const (
+	stateRunning = management.ContainerRunning
+	stateExited  = "exited"
+)
+
+// RunContainerCall keeps all RunContainer arguments to assert them in tests.
+type RunContainerCall struct {
+	ImageName            string
+	ContainerName        string
+	MountPoints          []management.MountPoint
+	EnvironmentVariables []management.EnvironmentVariable
+	PortBindings         []management.PortBinding
+	Labels               []management.Label
+	NetworkMode          management.NetworkMode
+	UserMapping          management.UserMapping
+	ResourceLimits       management.ResourceLimits
+	SecurityOptions      management.SecurityOptions
+	Interactive          bool
+}
+
+type StopContainerCall struct {
+	ContainerName string
+	Timeout       int
+}
+
+type RemoveContainerCall struct {
+	ContainerName string
+	Force         bool
+}
+
+type PrintContainerLogsCall struct {
+	ContainerName string
+	Options       management.LogsOptions
+}
+
+type ExecCall struct {
+	ContainerName string
+	Options       management.ExecOptions
+}
+
+// ExecHandler simulates command run by ExecInContainer.
+type ExecHandler func(options management.ExecOptions, stdin io.Reader, stdout io.Writer, stderr io.Writer) (exitCode int)
+
+type fakeContainer struct {
+	info management.ContainerInfo
+	logs []byte
+}
+
+// Manager simulates container manager in memory, it is used by tests instead of docker or podman.
+type Manager struct {
+	mu sync.Mutex
+
+	images       map[string][]management.Label
+	remoteImages map[string][]management.Label
+	containers   map[string]*fakeContainer
+	lastId       int
+
+	runContainerCalls       []RunContainerCall
+	stopContainerCalls      []StopContainerCall
+	removeContainerCalls    []RemoveContainerCall
+	printContainerLogsCalls []PrintContainerLogsCall
+	execCalls               []ExecCall
+	execHandler             ExecHandler
+}
+
+func NewFakeManager() *Manager {
+	return &Manager{
+		images:       map[string][]management.Label{},
+		remoteImages: map[string][]management.Label{},
+		containers:   map[string]*fakeContainer{},
+	}
+}
+
+func (m *Manager) AddImage(imageName string, labels []management.Label) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.images[imageName] = labels
+}
+
+// AddRemoteImage registers image which can be pulled by PullImage.
+func (m *Manager) AddRemoteImage(imageName string, labels []management.Label) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remoteImages[imageName] = labels
+}
+
+func (m *Manager) AppendContainerLogs(containerName string, logs string) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.containers[containerName]
+	if !ok {
+		return fmt.Errorf("container '%s' not found", containerName)
+	}
+	c.logs = append(c.logs, logs...)
+	return nil
+}
+
+func (m *Manager) SetExecHandler(handler ExecHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.execHandler = handler
+}
+
+func (m *Manager) RunContainerCalls() []RunContainerCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]RunContainerCall(nil), m.runContainerCalls...)
+}
+
+func (m *Manager) StopContainerCalls() []StopContainerCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]StopContainerCall(nil), m.stopContainerCalls...)
+}
+
+func (m *Manager) RemoveContainerCalls() []RemoveContainerCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]RemoveContainerCall(nil), m.removeContainerCalls...)
+}
+
+func (m *Manager) PrintContainerLogsCalls() []PrintContainerLogsCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]PrintContainerLogsCall(nil), m.printContainerLogsCalls...)
+}
+
+func (m *Manager) ExecCalls() []ExecCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]ExecCall(nil), m.execCalls...)
+}
+
+func (m *Manager) Init(_ context.Context) (err error) {
+	return nil
+}
+
+// LoadImage reads docker-archive tarball and registers images from its 'manifest.json'.
+func (m *Manager) LoadImage(
+	_ context.Context,
+	r io.Reader,
+) (imageNames []string, err error) {
+	tr := tar.NewReader(r)
+	for {
+		var header *tar.Header
+		header, err = tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read image tarball: %w", err)
+		}
+		if header.Name != "manifest.json" {
+			continue
+		}
+
+		var manifest []struct {
+			RepoTags []string
+		}
+		err = json.NewDecoder(tr).Decode(&manifest)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse image tarball manifest: %w", err)
+		}
+		for _, item := range manifest {
+			imageNames = append(imageNames, item.RepoTags...)
+		}
+	}
+	if len(imageNames) == 0 {
+		return nil, errors.New("cannot load image: no image tags in tarball")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, imageName := range imageNames {
+		if _, ok := m.images[imageName]; !ok {
+			m.images[imageName] = nil
+		}
+	}
+	return imageNames, nil
+}
+
+func (m *Manager) GetImageLabels(
+	_ context.Context,
+	imageName string,
+) (labels []management.Label, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	labels, ok := m.images[imageName]
+	if !ok {
+		return nil, fmt.Errorf("cannot inspect image '%s': not found", imageName)
+	}
+	return append([]management.Label(nil), labels...), nil
+}
+
+func (m *Manager) ListImages(
+	_ context.Context,
+	labelName string,
+) (images []management.ImageSummary, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for imageName, labels := range m.images {
+		summary := management.ImageSummary{
+			Id:     imageName,
+			Tags:   []string{imageName},
+			Labels: append([]management.Label(nil), labels...),
+		}
+		if _, ok := summary.GetLabel(labelName); labelName != "" && !ok {
+			continue
+		}
+		images = append(images, summary)
+	}
+	return images, nil
+}
+
+// BuildImage registers image with build labels under all tags.
+func (m *Manager) BuildImage(
+	_ context.Context,
+	options management.BuildOptions,
+	progress io.Writer,
+) (err error) {
+	if len(options.Tags) == 0 {
+		return errors.New("cannot build image: no tags")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tag := range options.Tags {
+		m.images[tag] = append([]management.Label(nil), options.Labels...)
+	}
+	_, _ = fmt.Fprintf(progress, "built %s\n", options.Tags[0])
+	return nil
+}
+
+func (m *Manager) IsImageExists(
+	_ context.Context,
+	imageName string,
+) (exists bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, exists = m.images[imageName]
+	return exists, nil
+}
+
+// PullImage copies image added by AddRemoteImage to local images.
+func (m *Manager) PullImage(
+	_ context.Context,
+	imageName string,
+	_ management.RegistryAuth,
+	progress io.Writer,
+) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	labels, ok := m.remoteImages[imageName]
+	if !ok {
+		return fmt.Errorf("cannot pull image '%s': not found", imageName)
+	}
+	m.images[imageName] = labels
+	_, _ = fmt.Fprintf(progress, "pulled %s\n", imageName)
+	return nil
+}
+
+func (m *Manager) RunContainer(
+	_ context.Context,
+	imageName string,
+	containerName string,
+	mountPoints []management.MountPoint,
+	environmentVariables []management.EnvironmentVariable,
+	portBindings []management.PortBinding,
+	labels []management.Label,
+	networkMode management.NetworkMode,
+	userMapping management.UserMapping,
+	resourceLimits management.ResourceLimits,
+	securityOptions management.SecurityOptions,
+	interactive bool,
+) (containerId string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.runContainerCalls = append(m.runContainerCalls, RunContainerCall{
+		ImageName:            imageName,
+		ContainerName:        containerName,
+		MountPoints:          mountPoints,
+		EnvironmentVariables: environmentVariables,
+		PortBindings:         portBindings,
+		Labels:               labels,
+		NetworkMode:          networkMode,
+		UserMapping:          userMapping,
+		ResourceLimits:       resourceLimits,
+		SecurityOptions:      securityOptions,
+		Interactive:          interactive,
+	})
+
+	if _, ok := m.images[imageName]; !ok {
+		return "", fmt.Errorf("cannot create container from image '%s': image not found", imageName)
+	}
+	if _, ok := m.containers[containerName]; ok {
+		return "", fmt.Errorf("cannot create container from image '%s': name '%s' is in use", imageName, containerName)
+	}
+
+	m.lastId++
+	containerId = fmt.Sprintf("fake%060d", m.lastId)
+
+	state := stateRunning
+	startedAt := time.Now()
+	if interactive {
+		state = stateExited
+		startedAt = time.Time{}
+	}
+
+	m.containers[containerName] = &fakeContainer{
+		info: management.ContainerInfo{
+			Id:        containerId,
+			Name:      containerName,
+			Image:     imageName,
+			State:     state,
+			StartedAt: startedAt,
+			Labels:    append([]management.Label{{Name: management.ManagedLabel, Value: "true"}}, labels...),
+		},
+	}
+	return containerId, nil
+}
+
+func (m *Manager) ListContainers(
+	_ context.Context,
+) (containers []management.ContainerInfo, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.containers {
+		containers = append(containers, c.info)
+	}
+	return containers, nil
+}
+
+func (m *Manager) IsContainerExists(
+	_ context.Context,
+	containerName string,
+) (exists bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, exists = m.containers[containerName]
+	return exists, nil
+}
+
+func (m *Manager) StartContainer(
+	_ context.Context,
+	containerName string,
+) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.containers[containerName]
+	if !ok {
+		return fmt.Errorf("cannot start container '%s': not found", containerName)
+	}
+	c.info.State = stateRunning
+	c.info.StartedAt = time.Now()
+	return nil
+}
+
+func (m *Manager) StopContainer(
+	_ context.Context,
+	containerName string,
+	timeout int,
+) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stopContainerCalls = append(m.stopContainerCalls, StopContainerCall{ContainerName: containerName, Timeout: timeout})
+
+	c, ok := m.containers[containerName]
+	if !ok {
+		return fmt.Errorf("cannot stop container '%s': not found", containerName)
+	}
+	c.info.State = stateExited
+	c.info.StartedAt = time.Time{}
+	return nil
+}
+
+func (m *Manager) RemoveContainer(
+	_ context.Context,
+	containerName string,
+	force bool,
+) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.removeContainerCalls = append(m.removeContainerCalls, RemoveContainerCall{ContainerName: containerName, Force: force})
+
+	c, ok := m.containers[containerName]
+	if !ok {
+		return fmt.Errorf("cannot remove container '%s': not found", containerName)
+	}
+	if c.info.State == stateRunning && !force {
+		return fmt.Errorf("cannot remove container '%s': container is running", containerName)
+	}
+	delete(m.containers, containerName)
+	return nil
+}
+
+func (m *Manager) ExecInContainer(
+	ctx context.Context,
+	containerName string,
+	options management.ExecOptions,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+) (exitCode int, err error) {
+	m.mu.Lock()
+	c, ok := m.containers[containerName]
+	if !ok || c.info.State != stateRunning {
+		m.mu.Unlock()
+		return 0, fmt.Errorf("cannot create exec in container '%s': container is not running", containerName)
+	}
+	m.execCalls = append(m.execCalls, ExecCall{ContainerName: containerName, Options: options})
+	handler := m.execHandler
+	m.mu.Unlock()
+
+	if handler == nil {
+		return 0, nil
+	}
+
+	// Backends stop waiting for exec once the context is done, the handler is left to finish aside.
+	exitCodes := make(chan int, 1)
+	go func() {
+		exitCodes <- handler(options, stdin, stdout, stderr)
+	}()
+	select {
+	case exitCode = <-exitCodes:
+		return exitCode, nil
+	case <-ctx.Done():
+		return 0, fmt.Errorf("cannot wait exec in container '%s': %w", containerName, ctx.Err())
+	}
+}
+
+func (m *Manager) PrintContainerLogs(
+	_ context.Context,
+	containerName string,
+	options management.LogsOptions,
+	stdout io.Writer,
+	_ io.Writer,
+) (err error) {
+	m.mu.Lock()
+	m.printContainerLogsCalls = append(m.printContainerLogsCalls, PrintContainerLogsCall{ContainerName: containerName, Options: options})
+	c, ok := m.containers[containerName]
+	if !ok {
+		m.mu.Unlock()
+		return fmt.Errorf("cannot get container logs from '%s': not found", containerName)
+	}
+	logs := append([]byte(nil), c.logs...)
+	m.mu.Unlock()
+
+	_, err = stdout.Write(logs)
+	if err != nil {
+		return fmt.Errorf("cannot print container logs from '%s': %w", containerName, err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package fake
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"testing"
+
+	"dev-runner/pkg/conainer/management"
+)
+
+const testImageName = "dev-test:1"
+
+func runContainer(m *Manager, containerName string, interactive bool) (containerId string, err error) {
+	return m.RunContainer(
+		context.Background(),
+		testImageName,
+		containerName,
+		nil,
+		nil,
+		nil,
+		[]management.Label{{Name: "dev.test", Value: "true"}},
+		management.NetworkBridge,
+		management.UserMapping{},
+		management.ResourceLimits{},
+		management.SecurityOptions{},
+		interactive,
+	)
+}
+
+func TestRunContainer(t *testing.T) {
+	m := NewFakeManager()
+
+	_, err := runContainer(m, "missing-image", false)
+	if err == nil {
+		t.Errorf("run of missing image must fail")
+	}
+
+	m.AddImage(testImageName, nil)
+	containerId, err := runContainer(m, "dev", false)
+	if err != nil {
+		t.Fatalf("run failed: %s", err)
+	}
+	_, err = runContainer(m, "dev", false)
+	if err == nil {
+		t.Errorf("run with name in use must fail")
+	}
+
+	if calls := m.RunContainerCalls(); len(calls) != 3 || calls[1].ContainerName != "dev" {
+		t.Errorf("expected 3 recorded run calls, got %+v", calls)
+	}
+
+	containers, err := m.ListContainers(context.Background())
+	if err != nil {
+		t.Fatalf("list failed: %s", err)
+	}
+	if len(containers) != 1 || containers[0].Id != containerId || containers[0].State != stateRunning {
+		t.Fatalf("expected running container '%s', got %+v", containerId, containers)
+	}
+	labels := containers[0].Labels
+	if !slices.Contains(labels, management.Label{Name: management.ManagedLabel, Value: "true"}) ||
+		!slices.Contains(labels, management.Label{Name: "dev.test", Value: "true"}) {
+		t.Errorf("expected managed and run labels, got %+v", labels)
+	}
+}
+
+func TestExecInContainer(t *testing.T) {
+	m := NewFakeManager()
+	m.AddImage(testImageName, nil)
+	_, err := runContainer(m, "dev", false)
+	if err != nil {
+		t.Fatalf("run failed: %s", err)
+	}
+	_, err = runContainer(m, "dev-interactive", true)
+	if err != nil {
+		t.Fatalf("interactive run failed: %s", err)
+	}
+
+	m.SetExecHandler(func(options management.ExecOptions, _ io.Reader, stdout io.Writer, _ io.Writer) int {
+		_, _ = io.WriteString(stdout, options.User)
+		return 3
+	})
+
+	var stdout bytes.Buffer
+	exitCode, err := m.ExecInContainer(context.Background(), "dev", management.ExecOptions{User: "user"}, nil, &stdout, io.Discard)
+	if err != nil {
+		t.Fatalf("exec failed: %s", err)
+	}
+	if exitCode != 3 || stdout.String() != "user" {
+		t.Errorf("expected handler exit code 3 and output 'user', got %d and '%s'", exitCode, stdout.String())
+	}
+
+	// Interactive container has exited once run returned.
+	_, err = m.ExecInContainer(context.Background(), "dev-interactive", management.ExecOptions{}, nil, io.Discard, io.Discard)
+	if err == nil {
+		t.Errorf("exec in exited container must fail")
+	}
+
+	if calls := m.ExecCalls(); len(calls) != 1 || calls[0].ContainerName != "dev" {
+		t.Errorf("expected 1 recorded exec call, got %+v", calls)
+	}
+}
+
+func TestLoadImage(t *testing.T) {
+	var tarball bytes.Buffer
+	tw := tar.NewWriter(&tarball)
+	manifest := []byte(`[{"RepoTags": ["dev-test:1", "dev-test:latest"]}]`)
+	err := tw.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0o644, Size: int64(len(manifest))})
+	if err != nil {
+		t.Fatalf("cannot write tarball header: %s", err)
+	}
+	_, err = tw.Write(manifest)
+	if err != nil {
+		t.Fatalf("cannot write tarball manifest: %s", err)
+	}
+	err = tw.Close()
+	if err != nil {
+		t.Fatalf("cannot close tarball: %s", err)
+	}
+
+	m := NewFakeManager()
+	imageNames, err := m.LoadImage(context.Background(), &tarball)
+	if err != nil {
+		t.Fatalf("load failed: %s", err)
+	}
+	if !slices.Equal(imageNames, []string{"dev-test:1", "dev-test:latest"}) {
+		t.Errorf("expected tarball tags, got %v", imageNames)
+	}
+	_, err = m.GetImageLabels(context.Background(), "dev-test:latest")
+	if err != nil {
+		t.Errorf("loaded image must be inspectable: %s", err)
+	}
+
+	_, err = m.LoadImage(context.Background(), bytes.NewReader(nil))
+	if err == nil {
+		t.Errorf("load of tarball without tags must fail")
+	}
+}
@@ -11,13 +11,39 @@ type ContainerManager interface {
 	LoadImage(
 		ctx context.Context,
 		r io.Reader,
-	) (err error)
+	) (imageNames []string, err error)
 
 	GetImageLabels(
 		ctx context.Context,
 		imageName string,
 	) (labels []Label, err error)
 
+	// ListImages lists images having label with labelName, all images if labelName is empty.
+	ListImages(
+		ctx context.Context,
+		labelName string,
+	) (images []ImageSummary, err error)
+
+	// BuildImage builds and tags image and writes build output to progress.
+	BuildImage(
+		ctx context.Context,
+		options BuildOptions,
+		progress io.Writer,
+	) (err error)
+
+	IsImageExists(
+		ctx context.Context,
+		imageName string,
+	) (exists bool, err error)
+
+	// PullImage pulls image from registry and writes pull progress to progress.
+	PullImage(
+		ctx context.Context,
+		imageName string,
+		auth RegistryAuth,
+		progress io.Writer,
+	) (err error)
+
 	RunContainer(
 		ctx context.Context,
 		imageName string,
@@ -25,17 +51,56 @@ type ContainerManager interface {
 		mountPoints []MountPoint,
 		environmentVariables []EnvironmentVariable,
 		portBindings []PortBinding,
+		labels []Label,
 		networkMode NetworkMode,
+		userMapping UserMapping,
+		resourceLimits ResourceLimits,
+		securityOptions SecurityOptions,
+		interactive bool,
 	) (containerId string, err error)
 
+	ListContainers(
+		ctx context.Context,
+	) (containers []ContainerInfo, err error)
+
+	IsContainerExists(
+		ctx context.Context,
+		containerName string,
+	) (exists bool, err error)
+
+	StartContainer(
+		ctx context.Context,
+		containerName string,
+	) (err error)
+
+	// StopContainer stops container, it is killed when timeout in seconds is over.
+	// Negative timeout means the container manager default.
 	StopContainer(
 		ctx context.Context,
 		containerName string,
+		timeout int,
 	) (err error)
 
+	RemoveContainer(
+		ctx context.Context,
+		containerName string,
+		force bool,
+	) (err error)
+
+	ExecInContainer(
+		ctx context.Context,
+		containerName string,
+		options ExecOptions,
+		stdin io.Reader,
+		stdout io.Writer,
+		stderr io.Writer,
+	) (exitCode int, err error)
+
 	PrintContainerLogs(
 		ctx context.Context,
 		containerName string,
-		w io.Writer,
+		options LogsOptions,
+		stdout io.Writer,
+		stderr io.Writer,
 	) (err error)
 }
@@ -0,0 +1,78 @@
+package podman
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/common/pkg/config"
+
+	"dev-runner/pkg/conainer/management"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+const rootSocketPath = "/run/podman/podman.sock"
+
+// resolveConnection returns podman API URL and SSH identity in order:
+// explicit URL, named connection, CONTAINER_HOST, CONTAINER_CONNECTION,
+// default connection from containers.conf, rootless socket and root socket.
+func resolveConnection(connection management.ConnectionOptions) (uri string, identity string, err error) {
+	if connection.Url != "" {
+		return connection.Url, "", nil
+	}
+	if connection.Name != "" {
+		return getNamedConnection(connection.Name)
+	}
+	if containerHost := os.Getenv("CONTAINER_HOST"); containerHost != "" {
+		return containerHost, "", nil
+	}
+	if containerConnection := os.Getenv("CONTAINER_CONNECTION"); containerConnection != "" {
+		return getNamedConnection(containerConnection)
+	}
+
+	var cfg *config.Config
+	cfg, err = config.Default()
+	if err == nil {
+		var connection_ *config.Connection
+		connection_, err = cfg.GetConnection("", true)
+		if err == nil {
+			return connection_.URI, connection_.Identity, nil
+		}
+	}
+
+	socketPath := getRootlessSocketPath()
+	if socketPath == "" || !fp.IsExists(socketPath) {
+		socketPath = rootSocketPath
+	}
+	return "unix://" + socketPath, "", nil
+}
+
+func getNamedConnection(name string) (uri string, identity string, err error) {
+	var cfg *config.Config
+	cfg, err = config.Default()
+	if err != nil {
+		return "", "", fmt.Errorf("cannot read containers configuration: %w", err)
+	}
+
+	var connection_ *config.Connection
+	connection_, err = cfg.GetConnection(name, false)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot get podman connection '%s': %w", name, err)
+	}
+	return connection_.URI, connection_.Identity, nil
+}
+
+// getRootlessSocketPath returns the socket path of rootless podman service of current user,
+// or empty string when running as root.
+func getRootlessSocketPath() string {
+	if os.Getuid() == 0 {
+		return ""
+	}
+
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = filepath.Join("/run", "user", fmt.Sprint(os.Getuid()))
+	}
+	return filepath.Join(runtimeDir, "podman", "podman.sock")
+}
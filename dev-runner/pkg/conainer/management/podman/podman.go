@@ -1,64 +1,100 @@
 package podman
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"os"
+	"strconv"
+	"time"
 
 	"dev-runner/pkg/conainer/management"
 
+	buildahDefine "github.com/containers/buildah/define"
 	nettypes "github.com/containers/common/libnetwork/types"
+	"github.com/containers/podman/v5/libpod/define"
+	"github.com/containers/podman/v5/pkg/api/handlers"
 	"github.com/containers/podman/v5/pkg/bindings"
 	"github.com/containers/podman/v5/pkg/bindings/containers"
 	"github.com/containers/podman/v5/pkg/bindings/images"
+	"github.com/containers/podman/v5/pkg/bindings/system"
 	"github.com/containers/podman/v5/pkg/domain/entities/types"
 	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/distribution/reference"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
 type podmanManager struct {
-	conCtx context.Context
+	connection management.ConnectionOptions
+	conCtx     context.Context
 }
 
-func NewPodmanManager() management.ContainerManager {
-	return &podmanManager{}
+func NewPodmanManager(connection management.ConnectionOptions) management.ContainerManager {
+	return &podmanManager{connection: connection}
 }
 
 func (m *podmanManager) Init(ctx context.Context) (err error) {
+	var uri, identity string
+	uri, identity, err = resolveConnection(m.connection)
+	if err != nil {
+		return fmt.Errorf("cannot resolve podman connection: %w", err)
+	}
+
 	var conCtx context.Context
-	conCtx, err = bindings.NewConnection(ctx, "")
+	conCtx, err = bindings.NewConnectionWithIdentity(ctx, uri, identity, false)
 	if err != nil {
-		return fmt.Errorf("cannot create posman connection: %w", err)
+		return fmt.Errorf("cannot create podman connection to '%s': %w", uri, err)
 	}
 
 	m.conCtx = conCtx
 	return err
 }
 
+// connectionContext is cancelled with the call context and carries podman connection values,
+// so the call deadline applies to requests made through the connection.
+type connectionContext struct {
+	context.Context
+	connection context.Context
+}
+
+func (c connectionContext) Value(key any) any {
+	value := c.Context.Value(key)
+	if value != nil {
+		return value
+	}
+	return c.connection.Value(key)
+}
+
+func (m *podmanManager) withConnection(ctx context.Context) context.Context {
+	return connectionContext{Context: ctx, connection: m.conCtx}
+}
+
 func (m *podmanManager) LoadImage(
-	_ context.Context,
+	ctx context.Context,
 	r io.Reader,
-) (err error) {
-	_, err = images.Load(m.conCtx, r)
+) (imageNames []string, err error) {
+	var report_ *types.ImageLoadReport
+	report_, err = images.Load(m.withConnection(ctx), r)
 	if err != nil {
-		return fmt.Errorf("cannot load image: %w", err)
+		return nil, fmt.Errorf("cannot load image: %w", err)
 	}
-	return nil
+	return report_.Names, nil
 }
 
 func (m *podmanManager) GetImageLabels(
-	_ context.Context,
+	ctx context.Context,
 	imageName string,
 ) (labels []management.Label, err error) {
 	var inspect *types.ImageInspectReport
-	inspect, err = images.GetImage(m.conCtx, imageName, nil)
+	inspect, err = images.GetImage(m.withConnection(ctx), imageName, nil)
 	if err != nil {
-		return nil, fmt.Errorf("cannot inspect image '%s': %w", err)
+		return nil, fmt.Errorf("cannot inspect image '%s': %w", imageName, err)
 	}
 
 	if len(inspect.Config.Labels) > 0 {
-		labels = make([]management.Label, len(inspect.Config.Labels))
+		labels = make([]management.Label, 0, len(inspect.Config.Labels))
 		for name, value := range inspect.Config.Labels {
 			labels = append(labels, management.Label{Name: name, Value: value})
 		}
@@ -66,73 +102,176 @@ func (m *podmanManager) GetImageLabels(
 	return labels, nil
 }
 
+func (m *podmanManager) ListImages(
+	ctx context.Context,
+	labelName string,
+) (imageSummaries []management.ImageSummary, err error) {
+	listOptions := new(images.ListOptions)
+	if labelName != "" {
+		listOptions.WithFilters(map[string][]string{"label": {labelName}})
+	}
+
+	var summaries_ []*types.ImageSummary
+	summaries_, err = images.List(m.withConnection(ctx), listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list images: %w", err)
+	}
+
+	for _, item := range summaries_ {
+		summary := management.ImageSummary{
+			Id:      item.ID,
+			Tags:    item.RepoTags,
+			Created: time.Unix(item.Created, 0),
+			Size:    item.Size,
+		}
+		for name, value := range item.Labels {
+			summary.Labels = append(summary.Labels, management.Label{Name: name, Value: value})
+		}
+		imageSummaries = append(imageSummaries, summary)
+	}
+	return imageSummaries, nil
+}
+
+func (m *podmanManager) BuildImage(
+	ctx context.Context,
+	options management.BuildOptions,
+	progress io.Writer,
+) (err error) {
+	buildArgs_ := make(map[string]string, len(options.BuildArgs))
+	for _, item := range options.BuildArgs {
+		buildArgs_[item.Name] = item.Value
+	}
+
+	var labels_ []string
+	for _, item := range options.Labels {
+		labels_ = append(labels_, fmt.Sprintf("%s=%s", item.Name, item.Value))
+	}
+
+	buildOptions := types.BuildOptions{
+		BuildOptions: buildahDefine.BuildOptions{
+			ContextDirectory:       options.ContextDir,
+			Args:                   buildArgs_,
+			Labels:                 labels_,
+			Output:                 options.Tags[0],
+			AdditionalTags:         options.Tags[1:],
+			Out:                    progress,
+			Err:                    progress,
+			ReportWriter:           progress,
+			RemoveIntermediateCtrs: true,
+		},
+	}
+
+	_, err = images.Build(m.withConnection(ctx), []string{options.Dockerfile}, buildOptions)
+	if err != nil {
+		return fmt.Errorf("cannot build image '%s': %w", options.Tags[0], err)
+	}
+	return nil
+}
+
+func (m *podmanManager) IsImageExists(
+	ctx context.Context,
+	imageName string,
+) (exists bool, err error) {
+	exists, err = images.Exists(m.withConnection(ctx), imageName, nil)
+	if err != nil {
+		return false, fmt.Errorf("cannot check image '%s' exists: %w", imageName, err)
+	}
+	return exists, nil
+}
+
+func (m *podmanManager) PullImage(
+	ctx context.Context,
+	imageName string,
+	auth management.RegistryAuth,
+	progress io.Writer,
+) (err error) {
+	pullOptions := new(images.PullOptions)
+	pullOptions.WithProgressWriter(progress)
+	// Podman takes credentials from its auth file and docker config itself if none are given.
+	if auth.Username != "" {
+		pullOptions.WithUsername(auth.Username)
+		pullOptions.WithPassword(auth.Password)
+	}
+
+	var insecure bool
+	insecure, err = isLocalRegistry(imageName)
+	if err != nil {
+		return err
+	}
+	// Docker allows plain HTTP for local registries by default, so does this.
+	if insecure {
+		pullOptions.WithSkipTLSVerify(true)
+	}
+
+	if auth.IdentityToken != "" {
+		err = pullWithIdentityToken(m.withConnection(ctx), imageName, auth, insecure, progress)
+	} else {
+		_, err = images.Pull(m.withConnection(ctx), imageName, pullOptions)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot pull image '%s': %w", imageName, err)
+	}
+	return nil
+}
+
 func (m *podmanManager) RunContainer(
-	_ context.Context,
+	ctx context.Context,
 	imageName string,
 	containerName string,
 	mountPoints []management.MountPoint,
 	environmentVariables []management.EnvironmentVariable,
 	portBindings []management.PortBinding,
+	labels []management.Label,
 	networkMode management.NetworkMode,
+	userMapping management.UserMapping,
+	resourceLimits management.ResourceLimits,
+	securityOptions management.SecurityOptions,
+	interactive bool,
 ) (containerId string, err error) {
-	var environmentVariables_ map[string]string
-	for _, item := range environmentVariables {
-		environmentVariables_[os.ExpandEnv(item.Name)] = os.ExpandEnv(item.Value)
-	}
-
-	var mountPoints_ []specs.Mount
-	for _, item := range mountPoints {
-		mountPoints_ = append(
-			mountPoints_,
-			specs.Mount{
-				Destination: item.ContainerPath,
-				Source:      item.HostPath,
-			},
-		)
-	}
-
-	var portBindings_ []nettypes.PortMapping
-	if len(portBindings) > 0 {
-		for _, item := range portBindings {
-			portBindings_ = append(
-				portBindings_,
-				nettypes.PortMapping{
-					ContainerPort: uint16(item.ContainerPort),
-					HostPort:      uint16(item.HostPort),
-				},
-			)
+	var rootless bool
+	if userMapping.Enabled {
+		rootless, err = m.isRootless(ctx)
+		if err != nil {
+			return "", err
 		}
 	}
 
-	var networkMode_ specgen.NamespaceMode
-	networkMode_, err = getNetworkMode(networkMode)
+	var s *specgen.SpecGenerator
+	s, err = buildSpecGenerator(
+		imageName,
+		containerName,
+		mountPoints,
+		environmentVariables,
+		portBindings,
+		labels,
+		networkMode,
+		userMapping,
+		resourceLimits,
+		securityOptions,
+		interactive,
+		rootless,
+	)
 	if err != nil {
 		return "", err
 	}
 
-	s := specgen.NewSpecGenerator(imageName, false)
-	s.Name = containerName
-	s.CapAdd = []string{
-		"CAP_AUDIT_WRITE",
-		"SYS_PTRACE",
-		"NET_RAW",
-		"NET_ADMIN",
-	}
-	s.Env = environmentVariables_
-	s.Mounts = mountPoints_
-	s.PortMappings = portBindings_
-	s.SeccompPolicy = "unconfined"
-	s.NetNS.NSMode = networkMode_
-
 	var containerResp_ types.ContainerCreateResponse
-	containerResp_, err = containers.CreateWithSpec(m.conCtx, s, nil)
+	containerResp_, err = containers.CreateWithSpec(m.withConnection(ctx), s, nil)
 	if err != nil {
 		return "", fmt.Errorf("cannot create container from image '%s': %w", imageName, err)
 	}
 
 	containerId = containerResp_.ID
 
-	err = containers.Start(m.conCtx, containerName, nil)
+	if interactive {
+		err = m.runAttached(ctx, containerId)
+		if err != nil {
+			return "", fmt.Errorf("cannot run container '%s' from image '%s' attached: %w", containerId, imageName, err)
+		}
+		return containerId, nil
+	}
+
+	err = containers.Start(m.withConnection(ctx), containerName, nil)
 	if err != nil {
 		return "", fmt.Errorf("cannot start container '%s' from image '%s': %w", containerId, imageName, err)
 	}
@@ -140,68 +279,439 @@ func (m *podmanManager) RunContainer(
 	return containerId, nil
 }
 
+func (m *podmanManager) isRootless(ctx context.Context) (rootless bool, err error) {
+	var info_ *define.Info
+	info_, err = system.Info(m.withConnection(ctx), nil)
+	if err != nil {
+		return false, fmt.Errorf("cannot get podman info: %w", err)
+	}
+	return info_.Host.Security.Rootless, nil
+}
+
+// runAttached starts container with stdio attached and waits until it exits.
+func (m *podmanManager) runAttached(ctx context.Context, containerId string) (err error) {
+	attachReady := make(chan bool)
+	attachErr := make(chan error, 1)
+	go func() {
+		attachErr <- containers.Attach(m.withConnection(ctx), containerId, os.Stdin, os.Stdout, os.Stderr, attachReady, nil)
+	}()
+
+	select {
+	case <-attachReady:
+	case err = <-attachErr:
+		return fmt.Errorf("cannot attach to container: %w", err)
+	}
+
+	err = containers.Start(m.withConnection(ctx), containerId, nil)
+	if err != nil {
+		return fmt.Errorf("cannot start container: %w", err)
+	}
+
+	var exitCode int32
+	exitCode, err = containers.Wait(m.withConnection(ctx), containerId, nil)
+	if err != nil {
+		return fmt.Errorf("container wait failed: %w", err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("container exited with code %d", exitCode)
+	}
+	return nil
+}
+
+func (m *podmanManager) ListContainers(
+	ctx context.Context,
+) (containerInfos []management.ContainerInfo, err error) {
+	options := new(containers.ListOptions)
+	options.
+		WithAll(true).
+		WithFilters(map[string][]string{"label": {management.ManagedLabel}})
+
+	var list_ []types.ListContainer
+	list_, err = containers.List(m.withConnection(ctx), options)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list containers: %w", err)
+	}
+
+	for _, item := range list_ {
+		var name string
+		if len(item.Names) > 0 {
+			name = item.Names[0]
+		}
+
+		var startedAt time.Time
+		if item.State == "running" && item.StartedAt > 0 {
+			startedAt = time.Unix(item.StartedAt, 0)
+		}
+
+		var labels []management.Label
+		for labelName, labelValue := range item.Labels {
+			labels = append(labels, management.Label{Name: labelName, Value: labelValue})
+		}
+
+		containerInfos = append(
+			containerInfos,
+			management.ContainerInfo{
+				Id:        item.ID,
+				Name:      name,
+				Image:     item.Image,
+				State:     item.State,
+				StartedAt: startedAt,
+				Labels:    labels,
+			},
+		)
+	}
+	return containerInfos, nil
+}
+
+func (m *podmanManager) IsContainerExists(
+	ctx context.Context,
+	containerName string,
+) (exists bool, err error) {
+	exists, err = containers.Exists(m.withConnection(ctx), containerName, nil)
+	if err != nil {
+		return false, fmt.Errorf("cannot check container '%s' exists: %w", containerName, err)
+	}
+	return exists, nil
+}
+
+func (m *podmanManager) StartContainer(
+	ctx context.Context,
+	containerName string,
+) (err error) {
+	err = containers.Start(m.withConnection(ctx), containerName, nil)
+	if err != nil {
+		return fmt.Errorf("cannot start container '%s': %w", containerName, err)
+	}
+	return nil
+}
+
 func (m *podmanManager) StopContainer(
-	_ context.Context,
+	ctx context.Context,
 	containerName string,
+	timeout int,
 ) (err error) {
-	err = containers.Stop(m.conCtx, containerName, nil)
+	stopOptions := new(containers.StopOptions)
+	if timeout >= 0 {
+		stopOptions.WithTimeout(uint(timeout))
+	}
+
+	err = containers.Stop(m.withConnection(ctx), containerName, stopOptions)
 	if err != nil {
 		return fmt.Errorf("cannot stop container '%s': %w", containerName, err)
 	}
+	return nil
+}
 
+func (m *podmanManager) RemoveContainer(
+	ctx context.Context,
+	containerName string,
+	force bool,
+) (err error) {
 	removeOptions := new(containers.RemoveOptions)
-	removeOptions.WithVolumes(true)
-	_, err = containers.Remove(m.conCtx, containerName, removeOptions)
+	removeOptions.
+		WithVolumes(true).
+		WithForce(force)
+	_, err = containers.Remove(m.withConnection(ctx), containerName, removeOptions)
 	if err != nil {
 		return fmt.Errorf("cannot remove container '%s': %w", containerName, err)
 	}
-
 	return nil
 }
 
+func (m *podmanManager) ExecInContainer(
+	ctx context.Context,
+	containerName string,
+	options management.ExecOptions,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+) (exitCode int, err error) {
+	var environmentVariables_ []string
+	for _, item := range options.EnvironmentVariables {
+		environmentVariables_ = append(environmentVariables_, fmt.Sprintf("%s=%s", item.Name, item.Value))
+	}
+
+	execConfig := new(handlers.ExecCreateConfig)
+	execConfig.User = options.User
+	execConfig.Tty = options.Tty
+	execConfig.AttachStdin = options.AttachStdin
+	execConfig.AttachStdout = true
+	execConfig.AttachStderr = true
+	execConfig.Env = environmentVariables_
+	execConfig.WorkingDir = options.WorkDir
+	execConfig.Cmd = options.Cmd
+
+	var sessionId string
+	sessionId, err = containers.ExecCreate(m.withConnection(ctx), containerName, execConfig)
+	if err != nil {
+		return 0, fmt.Errorf("cannot create exec in container '%s': %w", containerName, err)
+	}
+
+	startOptions := new(containers.ExecStartAndAttachOptions)
+	startOptions.
+		WithOutputStream(stdout).
+		WithErrorStream(stderr).
+		WithAttachOutput(true).
+		WithAttachError(true)
+	if options.AttachStdin {
+		startOptions.
+			WithInputStream(*bufio.NewReader(stdin)).
+			WithAttachInput(true)
+	}
+
+	err = containers.ExecStartAndAttach(m.withConnection(ctx), sessionId, startOptions)
+	if ctx.Err() != nil {
+		return 0, fmt.Errorf("cannot wait exec in container '%s': %w", containerName, ctx.Err())
+	}
+	if err != nil {
+		return 0, fmt.Errorf("cannot start exec in container '%s': %w", containerName, err)
+	}
+
+	var inspect_ *define.InspectExecSession
+	inspect_, err = containers.ExecInspect(m.withConnection(ctx), sessionId, nil)
+	if err != nil {
+		return 0, fmt.Errorf("cannot inspect exec in container '%s': %w", containerName, err)
+	}
+	return inspect_.ExitCode, nil
+}
+
 func (m *podmanManager) PrintContainerLogs(
-	_ context.Context,
+	ctx context.Context,
 	containerName string,
-	w io.Writer,
+	options management.LogsOptions,
+	stdout io.Writer,
+	stderr io.Writer,
 ) (err error) {
-	done := make(chan bool)
-	stdOut := make(chan string, 20)
-	stdErr := make(chan string, 20)
+	// Channels are unbuffered and read by single goroutine,
+	// so frames are written in the same order they are received.
+	stdOut := make(chan string)
+	stdErr := make(chan string)
+	done := make(chan struct{})
 
 	go func() {
-		for {
+		defer close(done)
+		for stdOut != nil || stdErr != nil {
 			select {
-			case msg := <-stdOut:
-				_, _ = w.Write([]byte(msg))
-			case msg := <-stdErr:
-				_, _ = w.Write([]byte(msg))
-			case <-done:
-				return
+			case msg, ok := <-stdOut:
+				if !ok {
+					stdOut = nil
+					continue
+				}
+				_, _ = io.WriteString(stdout, msg)
+			case msg, ok := <-stdErr:
+				if !ok {
+					stdErr = nil
+					continue
+				}
+				_, _ = io.WriteString(stderr, msg)
 			}
 		}
 	}()
 
-	options := new(containers.LogOptions)
-	options.
+	logOptions := new(containers.LogOptions)
+	logOptions.
 		WithStderr(true).
 		WithStdout(true).
-		WithTimestamps(true)
+		WithTimestamps(options.Timestamps).
+		WithFollow(options.Follow)
+	if options.Since != "" {
+		logOptions.WithSince(options.Since)
+	}
+	if options.Until != "" {
+		logOptions.WithUntil(options.Until)
+	}
+	if options.Tail != "" {
+		logOptions.WithTail(options.Tail)
+	}
 
 	err = containers.Logs(
-		m.conCtx,
+		m.withConnection(ctx),
 		containerName,
-		options,
+		logOptions,
 		stdOut,
 		stdErr,
 	)
-	if err != nil {
+	close(stdOut)
+	close(stdErr)
+	<-done
+
+	if err != nil && ctx.Err() == nil {
 		return fmt.Errorf("cannot get container logs from '%s': %w", containerName, err)
 	}
-	done <- true
 
 	return nil
 }
 
+// buildSpecGenerator translates RunContainer arguments into podman container spec,
+// rootless tells whether podman service runs rootless.
+func buildSpecGenerator(
+	imageName string,
+	containerName string,
+	mountPoints []management.MountPoint,
+	environmentVariables []management.EnvironmentVariable,
+	portBindings []management.PortBinding,
+	labels []management.Label,
+	networkMode management.NetworkMode,
+	userMapping management.UserMapping,
+	resourceLimits management.ResourceLimits,
+	securityOptions management.SecurityOptions,
+	interactive bool,
+	rootless bool,
+) (s *specgen.SpecGenerator, err error) {
+	environmentVariables_ := make(map[string]string, len(environmentVariables))
+	for _, item := range environmentVariables {
+		environmentVariables_[os.ExpandEnv(item.Name)] = os.ExpandEnv(item.Value)
+	}
+
+	var userNS_ specgen.Namespace
+	if userMapping.Enabled {
+		if rootless {
+			// Rootless podman maps the host user to the container user itself.
+			userNS_ = specgen.Namespace{
+				NSMode: specgen.KeepID,
+				Value:  fmt.Sprintf("uid=%d,gid=%d", userMapping.ContainerUid, userMapping.ContainerGid),
+			}
+		} else {
+			// Dev images entrypoint changes the container user ids to the host ones.
+			environmentVariables_[management.UserUidEnvironmentVariable] = strconv.Itoa(userMapping.HostUid)
+			environmentVariables_[management.UserGidEnvironmentVariable] = strconv.Itoa(userMapping.HostGid)
+		}
+	}
+
+	var mountPoints_ []specs.Mount
+	var volumes_ []*specgen.NamedVolume
+	for _, item := range mountPoints {
+		switch item.GetType() {
+		case management.MountBind:
+			// Docker bind mounts are recursive, so are these.
+			options_ := []string{"rbind"}
+			if item.ReadOnly {
+				options_ = append(options_, "ro")
+			}
+			if item.SELinuxRelabel != "" {
+				options_ = append(options_, item.SELinuxRelabel)
+			}
+			mountPoints_ = append(
+				mountPoints_,
+				specs.Mount{
+					Type:        "bind",
+					Destination: os.ExpandEnv(item.ContainerPath),
+					Source:      os.ExpandEnv(item.HostPath),
+					Options:     options_,
+				},
+			)
+		case management.MountVolume:
+			var options_ []string
+			if item.ReadOnly {
+				options_ = append(options_, "ro")
+			}
+			volumes_ = append(
+				volumes_,
+				&specgen.NamedVolume{
+					Name:    item.VolumeName,
+					Dest:    os.ExpandEnv(item.ContainerPath),
+					Options: options_,
+				},
+			)
+		case management.MountTmpfs:
+			var options_ []string
+			if item.ReadOnly {
+				options_ = append(options_, "ro")
+			}
+			if item.TmpfsSize != 0 {
+				options_ = append(options_, fmt.Sprintf("size=%d", item.TmpfsSize))
+			}
+			if item.TmpfsMode != 0 {
+				options_ = append(options_, fmt.Sprintf("mode=%o", item.TmpfsMode))
+			}
+			mountPoints_ = append(
+				mountPoints_,
+				specs.Mount{
+					Type:        "tmpfs",
+					Source:      "tmpfs",
+					Destination: os.ExpandEnv(item.ContainerPath),
+					Options:     options_,
+				},
+			)
+		default:
+			return nil, fmt.Errorf("unsupported mount type '%s'", item.Type)
+		}
+	}
+
+	var portBindings_ []nettypes.PortMapping
+	if len(portBindings) > 0 {
+		for _, item := range portBindings {
+			portBindings_ = append(
+				portBindings_,
+				nettypes.PortMapping{
+					HostIP:        item.HostAddress,
+					ContainerPort: uint16(item.ContainerPort),
+					HostPort:      uint16(item.HostPort),
+					Protocol:      "tcp",
+				},
+			)
+		}
+	}
+
+	labels_ := map[string]string{management.ManagedLabel: "true"}
+	for _, item := range labels {
+		labels_[item.Name] = item.Value
+	}
+
+	var networkMode_ specgen.NamespaceMode
+	networkMode_, err = getNetworkMode(networkMode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Docker fails on missing profile as it reads the content, so does this.
+	if securityOptions.SeccompProfile != "" && securityOptions.SeccompProfile != management.SeccompUnconfined {
+		_, err = os.Stat(securityOptions.SeccompProfile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read seccomp profile '%s': %w", securityOptions.SeccompProfile, err)
+		}
+	}
+
+	s = specgen.NewSpecGenerator(imageName, false)
+	s.Name = containerName
+	s.CapAdd = securityOptions.CapAdd
+	s.CapDrop = securityOptions.CapDrop
+	s.Env = environmentVariables_
+	s.Labels = labels_
+	s.Mounts = mountPoints_
+	s.Volumes = volumes_
+	s.PortMappings = portBindings_
+	s.SeccompProfilePath = securityOptions.SeccompProfile
+	if securityOptions.NoNewPrivileges {
+		s.NoNewPrivileges = &securityOptions.NoNewPrivileges
+	}
+	s.NetNS.NSMode = networkMode_
+	s.UserNS = userNS_
+	if userNS_.NSMode == specgen.KeepID {
+		// Otherwise podman runs the container as the host user and sshd cannot start.
+		s.User = "root"
+	}
+	s.ResourceLimits = getResources(resourceLimits)
+	if resourceLimits.ShmSize != 0 {
+		s.ShmSize = &resourceLimits.ShmSize
+	}
+	for _, item := range resourceLimits.Ulimits {
+		s.Rlimits = append(
+			s.Rlimits,
+			specs.POSIXRlimit{
+				Type: item.Name,
+				Soft: uint64(item.Soft),
+				Hard: uint64(item.Hard),
+			},
+		)
+	}
+	if interactive {
+		s.Terminal = &interactive
+		s.Stdin = &interactive
+	}
+	return s, nil
+}
+
 func getNetworkMode(mode management.NetworkMode) (network specgen.NamespaceMode, err error) {
 	switch mode {
 	case management.NetworkBridge:
@@ -212,3 +722,50 @@ func getNetworkMode(mode management.NetworkMode) (network specgen.NamespaceMode,
 		return specgen.Default, fmt.Errorf("network mode '%v' is not supported", mode)
 	}
 }
+
+// cpuPeriod is the default CFS period, the quota is derived from it for cpus limit.
+const cpuPeriod = 100000
+
+func getResources(limits management.ResourceLimits) (resources *specs.LinuxResources) {
+	if limits.Cpus == 0 && limits.Memory == 0 && limits.MemorySwap == 0 && limits.PidsLimit == 0 {
+		return nil
+	}
+
+	resources = &specs.LinuxResources{}
+	if limits.Cpus != 0 {
+		period := uint64(cpuPeriod)
+		quota := int64(limits.Cpus * cpuPeriod)
+		resources.CPU = &specs.LinuxCPU{Period: &period, Quota: &quota}
+	}
+	if limits.Memory != 0 || limits.MemorySwap != 0 {
+		resources.Memory = &specs.LinuxMemory{}
+		if limits.Memory != 0 {
+			resources.Memory.Limit = &limits.Memory
+		}
+		if limits.MemorySwap != 0 {
+			resources.Memory.Swap = &limits.MemorySwap
+		}
+	}
+	if limits.PidsLimit != 0 {
+		resources.Pids = &specs.LinuxPids{Limit: limits.PidsLimit}
+	}
+	return resources
+}
+
+func isLocalRegistry(imageName string) (local bool, err error) {
+	var named reference.Named
+	named, err = reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return false, fmt.Errorf("incorrect image name '%s': %w", imageName, err)
+	}
+
+	host := reference.Domain(named)
+	if h, _, splitErr := net.SplitHostPort(host); splitErr == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true, nil
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback(), nil
+}
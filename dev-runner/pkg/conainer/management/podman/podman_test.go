@@ -0,0 +1,228 @@
+package podman
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/containers/podman/v5/pkg/specgen"
+	"github.com/docker/docker/api/types/registry"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/conainer/management/conformance"
+)
+
+func TestBuildSpecGeneratorConformance(t *testing.T) {
+	conformance.Run(t, func(input conformance.Input) (c conformance.Container, err error) {
+		var s *specgen.SpecGenerator
+		s, err = buildInputSpecGenerator(input, false)
+		if err != nil {
+			return conformance.Container{}, err
+		}
+		return toConformanceContainer(t, s), nil
+	})
+}
+
+func TestBuildSpecGeneratorRootlessUserMapping(t *testing.T) {
+	input := conformance.NewInput()
+	input.UserMapping = management.UserMapping{
+		Enabled:      true,
+		HostUid:      1234,
+		HostGid:      5678,
+		ContainerUid: 1000,
+		ContainerGid: 0,
+	}
+
+	s, err := buildInputSpecGenerator(input, true)
+	if err != nil {
+		t.Fatalf("build failed: %s", err)
+	}
+
+	if s.UserNS.NSMode != specgen.KeepID || s.UserNS.Value != "uid=1000,gid=0" {
+		t.Errorf("user namespace: expected keep-id 'uid=1000,gid=0', got %+v", s.UserNS)
+	}
+	if s.User != "root" {
+		t.Errorf("user: expected 'root', got '%s'", s.User)
+	}
+	if _, ok := s.Env[management.UserUidEnvironmentVariable]; ok {
+		t.Errorf("env '%s' must not be set when podman maps user itself", management.UserUidEnvironmentVariable)
+	}
+}
+
+func buildInputSpecGenerator(input conformance.Input, rootless bool) (s *specgen.SpecGenerator, err error) {
+	return buildSpecGenerator(
+		input.ImageName,
+		input.ContainerName,
+		input.MountPoints,
+		input.EnvironmentVariables,
+		input.PortBindings,
+		input.Labels,
+		input.NetworkMode,
+		input.UserMapping,
+		input.ResourceLimits,
+		input.SecurityOptions,
+		input.Interactive,
+		rootless,
+	)
+}
+
+func toConformanceContainer(t *testing.T, s *specgen.SpecGenerator) (c conformance.Container) {
+	c = conformance.Container{
+		Image:       s.Image,
+		Network:     management.NetworkMode(s.NetNS.NSMode),
+		Env:         s.Env,
+		Labels:      s.Labels,
+		CapAdd:      s.CapAdd,
+		CapDrop:     s.CapDrop,
+		Interactive: s.Terminal != nil && *s.Terminal && s.Stdin != nil && *s.Stdin,
+	}
+
+	for _, item := range s.Mounts {
+		m := conformance.Mount{
+			Type:   management.MountType(item.Type),
+			Target: item.Destination,
+		}
+		if item.Type == "bind" {
+			m.Source = item.Source
+		}
+		for _, option := range item.Options {
+			name, value, _ := strings.Cut(option, "=")
+			switch name {
+			case "rbind":
+				m.Recursive = true
+			case "ro":
+				m.ReadOnly = true
+			case "z", "Z":
+				m.SELinuxRelabel = option
+			case "size":
+				size, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					t.Fatalf("invalid tmpfs size '%s'", value)
+				}
+				m.TmpfsSize = size
+			case "mode":
+				mode, err := strconv.ParseUint(value, 8, 32)
+				if err != nil {
+					t.Fatalf("invalid tmpfs mode '%s'", value)
+				}
+				m.TmpfsMode = os.FileMode(mode)
+			default:
+				t.Errorf("unexpected mount option '%s'", option)
+			}
+		}
+		c.Mounts = append(c.Mounts, m)
+	}
+	for _, item := range s.Volumes {
+		m := conformance.Mount{Type: management.MountVolume, Source: item.Name, Target: item.Dest}
+		for _, option := range item.Options {
+			if option != "ro" {
+				t.Errorf("unexpected volume option '%s'", option)
+			}
+			m.ReadOnly = true
+		}
+		c.Mounts = append(c.Mounts, m)
+	}
+
+	for _, item := range s.PortMappings {
+		if item.Protocol != "tcp" {
+			t.Errorf("port '%d' protocol: expected 'tcp', got '%s'", item.ContainerPort, item.Protocol)
+		}
+		c.PortBindings = append(c.PortBindings, management.PortBinding{
+			ContainerPort: int(item.ContainerPort),
+			HostPort:      int(item.HostPort),
+			HostAddress:   item.HostIP,
+		})
+	}
+
+	switch s.SeccompProfilePath {
+	case "", management.SeccompUnconfined:
+		c.SeccompProfile = s.SeccompProfilePath
+	default:
+		// Podman reads the profile by path, compare its content as docker passes it.
+		profile, err := os.ReadFile(s.SeccompProfilePath)
+		if err != nil {
+			t.Fatalf("cannot read seccomp profile: %s", err)
+		}
+		c.SeccompProfile = string(profile)
+	}
+	c.NoNewPrivileges = s.NoNewPrivileges != nil && *s.NoNewPrivileges
+
+	if resources := s.ResourceLimits; resources != nil {
+		if resources.CPU != nil && resources.CPU.Quota != nil && resources.CPU.Period != nil {
+			c.Cpus = float64(*resources.CPU.Quota) / float64(*resources.CPU.Period)
+		}
+		if resources.Memory != nil && resources.Memory.Limit != nil {
+			c.Memory = *resources.Memory.Limit
+		}
+		if resources.Memory != nil && resources.Memory.Swap != nil {
+			c.MemorySwap = *resources.Memory.Swap
+		}
+		if resources.Pids != nil {
+			c.PidsLimit = resources.Pids.Limit
+		}
+	}
+	if s.ShmSize != nil {
+		c.ShmSize = *s.ShmSize
+	}
+	for _, item := range s.Rlimits {
+		c.Ulimits = append(c.Ulimits, management.Ulimit{Name: item.Type, Soft: int64(item.Soft), Hard: int64(item.Hard)})
+	}
+	return c
+}
+
+func TestPullImagePassesIdentityToken(t *testing.T) {
+	var pulledAuth registry.AuthConfig
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/_ping") {
+			w.Header().Set("Libpod-API-Version", "5.2.0")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if !strings.HasSuffix(r.URL.Path, "/images/pull") {
+			http.NotFound(w, r)
+			return
+		}
+
+		authConfig, err := registry.DecodeAuthConfig(r.Header.Get("X-Registry-Auth"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		pulledAuth = *authConfig
+		_, _ = w.Write([]byte(`{"stream":"pulled\n"}{"images":["id"]}`))
+	}))
+	socketPath := filepath.Join(t.TempDir(), "podman.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("cannot listen podman socket: %s", err)
+	}
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	manager := NewPodmanManager(management.ConnectionOptions{Url: "unix://" + socketPath})
+	err = manager.Init(context.Background())
+	if err != nil {
+		t.Fatalf("cannot init podman manager: %s", err)
+	}
+
+	auth := management.RegistryAuth{ServerAddress: "registry.example.com", IdentityToken: "token"}
+	var progress strings.Builder
+	err = manager.PullImage(context.Background(), "registry.example.com/dev:1", auth, &progress)
+	if err != nil {
+		t.Fatalf("pull failed: %s", err)
+	}
+
+	if pulledAuth.IdentityToken != auth.IdentityToken || pulledAuth.ServerAddress != auth.ServerAddress {
+		t.Errorf("expected auth %+v to be passed, got %+v", auth, pulledAuth)
+	}
+	if progress.String() != "pulled\n" {
+		t.Errorf("expected pull progress to be printed, got %q", progress.String())
+	}
+}
@@ -0,0 +1,89 @@
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/containers/podman/v5/pkg/bindings"
+	"github.com/containers/podman/v5/pkg/domain/entities/types"
+	"github.com/docker/docker/api/types/registry"
+
+	"dev-runner/pkg/conainer/management"
+)
+
+// registryAuthHeader is the header podman API takes registry credentials from.
+const registryAuthHeader = "X-Registry-Auth"
+
+// pullWithIdentityToken pulls the image as images.Pull does, but passes the whole registry auth,
+// since the bindings encode username and password only and drop the identity token.
+func pullWithIdentityToken(
+	ctx context.Context,
+	imageName string,
+	auth management.RegistryAuth,
+	insecure bool,
+	progress io.Writer,
+) (err error) {
+	var con_ *bindings.Connection
+	con_, err = bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	var registryAuth_ string
+	registryAuth_, err = registry.EncodeAuthConfig(registry.AuthConfig{
+		Username:      auth.Username,
+		Password:      auth.Password,
+		ServerAddress: auth.ServerAddress,
+		IdentityToken: auth.IdentityToken,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot encode registry credentials: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("reference", imageName)
+	if insecure {
+		params.Set("tlsVerify", strconv.FormatBool(false))
+	}
+	header := http.Header{registryAuthHeader: []string{registryAuth_}}
+
+	var resp_ *bindings.APIResponse
+	resp_, err = con_.DoRequest(ctx, nil, http.MethodPost, "/images/pull", params, header)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp_.Body.Close()
+	}()
+
+	if !resp_.IsSuccess() {
+		return resp_.Process(nil)
+	}
+
+	var pullErrors []error
+	decoder := json.NewDecoder(resp_.Body)
+	for {
+		var report_ types.ImagePullReport
+		err = decoder.Decode(&report_)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("cannot read pull progress: %w", err)
+		}
+
+		if report_.Stream != "" {
+			_, _ = fmt.Fprint(progress, report_.Stream)
+		}
+		if report_.Error != "" {
+			pullErrors = append(pullErrors, errors.New(report_.Error))
+		}
+	}
+	return errors.Join(pullErrors...)
+}
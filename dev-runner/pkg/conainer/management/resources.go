@@ -0,0 +1,86 @@
+package management
+
+import (
+	"fmt"
+
+	"github.com/docker/go-units"
+)
+
+// ResourceLimits constrains resources of container. Zero values mean no limit.
+type ResourceLimits struct {
+	Cpus float64
+	// Memory limit in bytes.
+	Memory int64
+	// MemorySwap is the memory plus swap limit in bytes, -1 means unlimited swap.
+	MemorySwap int64
+	PidsLimit  int64
+	// ShmSize is the size of /dev/shm in bytes.
+	ShmSize int64
+	Ulimits []Ulimit
+}
+
+type Ulimit struct {
+	Name string
+	Soft int64
+	Hard int64
+}
+
+// Override returns limits where values set in other replace the ones of l.
+func (l ResourceLimits) Override(other ResourceLimits) ResourceLimits {
+	if other.Cpus != 0 {
+		l.Cpus = other.Cpus
+	}
+	if other.Memory != 0 {
+		l.Memory = other.Memory
+	}
+	if other.MemorySwap != 0 {
+		l.MemorySwap = other.MemorySwap
+	}
+	if other.PidsLimit != 0 {
+		l.PidsLimit = other.PidsLimit
+	}
+	if other.ShmSize != 0 {
+		l.ShmSize = other.ShmSize
+	}
+	l.Ulimits = append([]Ulimit{}, l.Ulimits...)
+	for _, item := range other.Ulimits {
+		l.Ulimits = setUlimit(l.Ulimits, item)
+	}
+	return l
+}
+
+// ParseSize parses human-readable size like '512m' or '4g' into bytes.
+// Empty value gives 0 and '-1' is kept to mean unlimited.
+func ParseSize(value string) (size int64, err error) {
+	if value == "" {
+		return 0, nil
+	}
+	if value == "-1" {
+		return -1, nil
+	}
+	size, err = units.RAMInBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("incorrect size '%s': %w", value, err)
+	}
+	return size, nil
+}
+
+// ParseUlimit parses ulimit in form 'name=soft[:hard]', e.g. 'nofile=1024:65536'.
+func ParseUlimit(value string) (ulimit Ulimit, err error) {
+	var ulimit_ *units.Ulimit
+	ulimit_, err = units.ParseUlimit(value)
+	if err != nil {
+		return Ulimit{}, fmt.Errorf("incorrect ulimit '%s': %w", value, err)
+	}
+	return Ulimit{Name: ulimit_.Name, Soft: ulimit_.Soft, Hard: ulimit_.Hard}, nil
+}
+
+func setUlimit(ulimits []Ulimit, ulimit Ulimit) []Ulimit {
+	for i := range ulimits {
+		if ulimits[i].Name == ulimit.Name {
+			ulimits[i] = ulimit
+			return ulimits
+		}
+	}
+	return append(ulimits, ulimit)
+}
@@ -0,0 +1,80 @@
+package management
+
+import (
+	"fmt"
+	"slices"
+)
+
+type SecurityProfile string
+
+const (
+	// SecurityProfileDebug allows debuggers and network tools, seccomp is disabled.
+	SecurityProfileDebug SecurityProfile = "debug"
+	// SecurityProfileDefault keeps the runtime defaults.
+	SecurityProfileDefault SecurityProfile = "default"
+	// SecurityProfileStrict drops all capabilities except ones needed by sshd and dev images entrypoint.
+	SecurityProfileStrict SecurityProfile = "strict"
+)
+
+// SeccompUnconfined disables seccomp filtering when used as seccomp profile.
+const SeccompUnconfined = "unconfined"
+
+func GetSecurityProfiles() []SecurityProfile {
+	return []SecurityProfile{SecurityProfileDebug, SecurityProfileDefault, SecurityProfileStrict}
+}
+
+type SecurityOptions struct {
+	CapAdd  []string
+	CapDrop []string
+	// SeccompProfile is the path to seccomp JSON profile or SeccompUnconfined,
+	// empty value means runtime default profile.
+	SeccompProfile  string
+	NoNewPrivileges bool
+}
+
+func GetSecurityOptions(profile SecurityProfile) (options SecurityOptions, err error) {
+	switch profile {
+	case SecurityProfileDebug:
+		return SecurityOptions{
+			CapAdd: []string{
+				"CAP_AUDIT_WRITE",
+				"SYS_PTRACE",
+				"NET_RAW",
+				"NET_ADMIN",
+			},
+			SeccompProfile: SeccompUnconfined,
+		}, nil
+	case SecurityProfileDefault:
+		return SecurityOptions{}, nil
+	case SecurityProfileStrict:
+		return SecurityOptions{
+			CapAdd: []string{
+				"AUDIT_WRITE",
+				"CHOWN",
+				"DAC_OVERRIDE",
+				"FOWNER",
+				"KILL",
+				"SETGID",
+				"SETUID",
+				"SYS_CHROOT",
+			},
+			CapDrop:         []string{"ALL"},
+			NoNewPrivileges: true,
+		}, nil
+	default:
+		return SecurityOptions{}, fmt.Errorf("unsupported security profile '%s', expected one of %v", profile, GetSecurityProfiles())
+	}
+}
+
+// Override returns options with capabilities of other appended and set values of other replacing the ones of o.
+func (o SecurityOptions) Override(other SecurityOptions) SecurityOptions {
+	o.CapAdd = append(slices.Clone(o.CapAdd), other.CapAdd...)
+	o.CapDrop = append(slices.Clone(o.CapDrop), other.CapDrop...)
+	if other.SeccompProfile != "" {
+		o.SeccompProfile = other.SeccompProfile
+	}
+	if other.NoNewPrivileges {
+		o.NoNewPrivileges = true
+	}
+	return o
+}
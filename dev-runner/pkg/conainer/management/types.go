@@ -1,9 +1,82 @@
 package management
 
+import (
+	"os"
+	"time"
+)
+
+// ManagedLabel marks containers created by dev-runner.
+const ManagedLabel = "dev.containers.runner.managed"
+
+// ConnectionOptions selects the container manager API to connect to,
+// by default it is discovered from environment and configuration files.
+type ConnectionOptions struct {
+	// Name of connection configured for container manager.
+	Name string
+	// Url of container manager API, it takes precedence over Name.
+	Url string
+}
+
+// RegistryAuth holds credentials of image registry, empty value means anonymous access.
+type RegistryAuth struct {
+	ServerAddress string
+	Username      string
+	Password      string
+	IdentityToken string
+}
+
+type BuildArg struct {
+	Name  string
+	Value string
+}
+
+type BuildOptions struct {
+	// ContextDir is the build context directory on host.
+	ContextDir string
+	// Dockerfile is the Dockerfile path on host, it may be outside of context dir.
+	Dockerfile string
+	// Tags are the image names given to built image, at least one is required.
+	Tags      []string
+	BuildArgs []BuildArg
+	Labels    []Label
+}
+
+type MountType string
+
+const (
+	MountBind   MountType = "bind"
+	MountVolume MountType = "volume"
+	MountTmpfs  MountType = "tmpfs"
+)
+
+// SELinux relabel options of bind mounts.
+const (
+	SELinuxRelabelShared  = "z"
+	SELinuxRelabelPrivate = "Z"
+)
+
 type MountPoint struct {
-	HostPath      string
+	// Type is the mount type, empty value means bind mount.
+	Type MountType
+	// HostPath is the source of bind mount.
+	HostPath string
+	// VolumeName is the name of volume mount, the volume is created if it does not exist.
+	VolumeName    string
 	ContainerPath string
 	ReadOnly      bool
+	// TmpfsSize is the size of tmpfs mount in bytes, 0 means runtime default.
+	TmpfsSize int64
+	// TmpfsMode is the permissions of tmpfs mount root, 0 means runtime default.
+	TmpfsMode os.FileMode
+	// SELinuxRelabel is SELinuxRelabelShared or SELinuxRelabelPrivate to relabel bind mount source.
+	SELinuxRelabel string
+}
+
+func (m MountPoint) GetType() MountType {
+	if m.Type == "" {
+		return MountBind
+	}
+	return m.Type
 }
 
 type EnvironmentVariable struct {
@@ -14,6 +87,7 @@ type EnvironmentVariable struct {
 type PortBinding struct {
 	ContainerPort int
 	HostPort      int
+	HostAddress   string
 }
 
 type Label struct {
@@ -21,6 +95,81 @@ type Label struct {
 	Value string
 }
 
+// Environment variables read by dev images entrypoint to adjust the container user.
+const (
+	UserUidEnvironmentVariable = "DEV_CONTAINER_USER_UID"
+	UserGidEnvironmentVariable = "DEV_CONTAINER_USER_GID"
+)
+
+// UserMapping maps the container user to the host user, so files in bind mounts keep host ownership.
+type UserMapping struct {
+	Enabled      bool
+	HostUid      int
+	HostGid      int
+	ContainerUid int
+	ContainerGid int
+}
+
+// ContainerRunning is the ContainerInfo state of running container, docker and podman report it alike.
+const ContainerRunning = "running"
+
+type ContainerInfo struct {
+	Id        string
+	Name      string
+	Image     string
+	State     string
+	StartedAt time.Time
+	Labels    []Label
+}
+
+func (i ContainerInfo) IsRunning() bool {
+	return i.State == ContainerRunning
+}
+
+func (i ContainerInfo) GetLabel(name string) (value string, ok bool) {
+	for _, label := range i.Labels {
+		if label.Name == name {
+			return label.Value, true
+		}
+	}
+	return "", false
+}
+
+type ImageSummary struct {
+	Id      string
+	Tags    []string
+	Created time.Time
+	// Size in bytes.
+	Size   int64
+	Labels []Label
+}
+
+func (i ImageSummary) GetLabel(name string) (value string, ok bool) {
+	for _, label := range i.Labels {
+		if label.Name == name {
+			return label.Value, true
+		}
+	}
+	return "", false
+}
+
+type ExecOptions struct {
+	Cmd                  []string
+	EnvironmentVariables []EnvironmentVariable
+	WorkDir              string
+	User                 string
+	Tty                  bool
+	AttachStdin          bool
+}
+
+type LogsOptions struct {
+	Follow     bool
+	Since      string
+	Until      string
+	Tail       string
+	Timestamps bool
+}
+
 type NetworkMode string
 
 const (
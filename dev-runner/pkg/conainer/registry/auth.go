@@ -0,0 +1,59 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/types"
+
+	"dev-runner/pkg/conainer/management"
+)
+
+// dockerHubAuthAddress is the key of Docker Hub credentials in docker config.
+const dockerHubAuthAddress = "https://index.docker.io/v1/"
+
+// GetAuth returns credentials of the image registry from docker config and credential helpers.
+// Empty auth is returned if there are no credentials for the registry.
+func GetAuth(imageName string) (auth management.RegistryAuth, err error) {
+	var serverAddress string
+	serverAddress, err = GetServerAddress(imageName)
+	if err != nil {
+		return management.RegistryAuth{}, err
+	}
+
+	var configFile *configfile.ConfigFile
+	configFile, err = config.Load(config.Dir())
+	if err != nil {
+		return management.RegistryAuth{}, fmt.Errorf("cannot load docker config: %w", err)
+	}
+
+	var authConfig types.AuthConfig
+	authConfig, err = configFile.GetAuthConfig(serverAddress)
+	if err != nil {
+		return management.RegistryAuth{}, fmt.Errorf("cannot get credentials of registry '%s': %w", serverAddress, err)
+	}
+
+	return management.RegistryAuth{
+		ServerAddress: serverAddress,
+		Username:      authConfig.Username,
+		Password:      authConfig.Password,
+		IdentityToken: authConfig.IdentityToken,
+	}, nil
+}
+
+// GetServerAddress returns registry address of the image as it is used as key in docker config.
+func GetServerAddress(imageName string) (serverAddress string, err error) {
+	var named reference.Named
+	named, err = reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return "", fmt.Errorf("incorrect image name '%s': %w", imageName, err)
+	}
+
+	domain := reference.Domain(named)
+	if domain == "docker.io" {
+		return dockerHubAuthAddress, nil
+	}
+	return domain, nil
+}
@@ -0,0 +1,145 @@
+package catalog
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/prototext"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/catalog/hub"
+	"dev-runner/pkg/dev/manifest"
+)
+
+const (
+	Kind    = "dev-runner-catalog"
+	Version = "v1"
+)
+
+func LoadFile(path string) (catalog *hub.Catalog, err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read catalog file '%s': %w", path, err)
+	}
+
+	catalog, err = Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse catalog file '%s': %w", path, err)
+	}
+	return catalog, nil
+}
+
+func Parse(data []byte) (catalog *hub.Catalog, err error) {
+	catalog = &hub.Catalog{}
+	err = prototext.Unmarshal(data, catalog)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal catalog: %w", err)
+	}
+
+	if catalog.GetKind() != Kind {
+		return nil, fmt.Errorf("unsupported catalog kind '%s', expected '%s'", catalog.GetKind(), Kind)
+	}
+	if catalog.GetVersion() != Version {
+		return nil, fmt.Errorf("unsupported catalog version '%s', expected '%s'", catalog.GetVersion(), Version)
+	}
+	return catalog, nil
+}
+
+func FindImage(catalog *hub.Catalog, name string) *hub.Image {
+	for _, image := range catalog.GetImages() {
+		if image.GetName() == name {
+			return image
+		}
+	}
+	return nil
+}
+
+func GetImageNames(catalog *hub.Catalog) (names []string) {
+	for _, image := range catalog.GetImages() {
+		names = append(names, image.GetName())
+	}
+	return names
+}
+
+// GetVariables returns catalog variables with overrides applied on top of them.
+func GetVariables(catalog *hub.Catalog, overrides map[string]string) manifest.Variables {
+	vars := manifest.Variables{}
+	for _, item := range catalog.GetVariables() {
+		vars[item.GetName()] = item.GetValue()
+	}
+	for name, value := range overrides {
+		vars[name] = value
+	}
+	return vars
+}
+
+// GetBuildOptions returns build options of the image, relative paths are resolved from catalogDir.
+func GetBuildOptions(image *hub.Image, vars manifest.Variables, catalogDir string) (options management.BuildOptions, err error) {
+	if len(image.GetTags()) == 0 {
+		return management.BuildOptions{}, fmt.Errorf("image '%s' must have tags", image.GetName())
+	}
+
+	options.ContextDir = resolvePath(catalogDir, vars.Expand(image.GetContextDir()))
+	options.Dockerfile = resolvePath(catalogDir, vars.Expand(image.GetDockerfile()))
+
+	for _, tag := range image.GetTags() {
+		options.Tags = append(options.Tags, vars.Expand(tag))
+	}
+	for _, item := range image.GetBuildArgs() {
+		options.BuildArgs = append(
+			options.BuildArgs,
+			management.BuildArg{
+				Name:  item.GetName(),
+				Value: vars.Expand(item.GetValue()),
+			},
+		)
+	}
+	for _, item := range image.GetLabels() {
+		options.Labels = append(
+			options.Labels,
+			management.Label{
+				Name:  item.GetName(),
+				Value: vars.Expand(item.GetValue()),
+			},
+		)
+	}
+
+	if image.GetManifest() != "" {
+		var label management.Label
+		label, err = getManifestLabel(resolvePath(catalogDir, vars.Expand(image.GetManifest())))
+		if err != nil {
+			return management.BuildOptions{}, err
+		}
+		options.Labels = append(options.Labels, label)
+	}
+	return options, nil
+}
+
+// getManifestLabel validates the manifest and encodes it into the image label.
+func getManifestLabel(path string) (label management.Label, err error) {
+	_, err = manifest.LoadFile(path)
+	if err != nil {
+		return management.Label{}, err
+	}
+
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return management.Label{}, fmt.Errorf("cannot read manifest file '%s': %w", path, err)
+	}
+
+	return management.Label{
+		Name:  manifest.ImageLabel,
+		Value: base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
+
+func resolvePath(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,4 @@
+// Package hub contains the dev-runner build catalog schema generated from catalog.proto.
+package hub
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative catalog.proto
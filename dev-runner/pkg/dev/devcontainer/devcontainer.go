@@ -0,0 +1,213 @@
+package devcontainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+// Config is the subset of devcontainer.json dev-runner understands,
+// see https://containers.dev/implementors/json_reference/.
+type Config struct {
+	Name              string            `json:"name"`
+	Image             string            `json:"image"`
+	Mounts            []Mount           `json:"mounts"`
+	ContainerEnv      map[string]string `json:"containerEnv"`
+	ForwardPorts      []Port            `json:"forwardPorts"`
+	RunArgs           []string          `json:"runArgs"`
+	PostCreateCommand Command           `json:"postCreateCommand"`
+	PostStartCommand  Command           `json:"postStartCommand"`
+	RemoteUser        string            `json:"remoteUser"`
+
+	// UnsupportedKeys are the keys of the file which are ignored.
+	UnsupportedKeys []string `json:"-"`
+}
+
+var supportedKeys = []string{
+	"$schema",
+	"name",
+	"image",
+	"mounts",
+	"containerEnv",
+	"forwardPorts",
+	"runArgs",
+	"postCreateCommand",
+	"postStartCommand",
+	"remoteUser",
+}
+
+// Mount is given either as a docker '--mount' flag value or as an object.
+type Mount struct {
+	Type     string `json:"type"`
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readonly"`
+
+	// UnsupportedOptions are the '--mount' options which are ignored.
+	UnsupportedOptions []string `json:"-"`
+}
+
+func (m *Mount) UnmarshalJSON(data []byte) (err error) {
+	var value string
+	if json.Unmarshal(data, &value) != nil {
+		type plainMount Mount
+		return json.Unmarshal(data, (*plainMount)(m))
+	}
+
+	for _, option := range strings.Split(value, ",") {
+		name, optionValue, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch name {
+		case "type":
+			m.Type = optionValue
+		case "source", "src":
+			m.Source = optionValue
+		case "target", "destination", "dst":
+			m.Target = optionValue
+		case "readonly", "ro":
+			m.ReadOnly = optionValue == "" || optionValue == "true" || optionValue == "1"
+		default:
+			m.UnsupportedOptions = append(m.UnsupportedOptions, option)
+		}
+	}
+	return nil
+}
+
+// Port is given either as a number or as a "host:port" string.
+type Port struct {
+	Host string
+	Port int
+}
+
+func (p *Port) UnmarshalJSON(data []byte) (err error) {
+	if json.Unmarshal(data, &p.Port) == nil {
+		return nil
+	}
+
+	var value string
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return fmt.Errorf("port must be number or string: %w", err)
+	}
+
+	host, port, found := strings.Cut(value, ":")
+	if !found {
+		host, port = "", value
+	}
+	p.Host = host
+	p.Port, err = strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("invalid port '%s': %w", value, err)
+	}
+	return nil
+}
+
+// Command is given as a shell string, as an argv array or as an object of named commands.
+type Command struct {
+	// Commands are ordered by name for the object form.
+	Commands [][]string
+}
+
+func (c *Command) UnmarshalJSON(data []byte) (err error) {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(data, &object) == nil {
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			var command []string
+			command, err = parseCommand(object[name])
+			if err != nil {
+				return fmt.Errorf("invalid command '%s': %w", name, err)
+			}
+			c.Commands = append(c.Commands, command)
+		}
+		return nil
+	}
+
+	var command []string
+	command, err = parseCommand(data)
+	if err != nil {
+		return err
+	}
+	if len(command) != 0 {
+		c.Commands = append(c.Commands, command)
+	}
+	return nil
+}
+
+func parseCommand(data []byte) (command []string, err error) {
+	var shell string
+	if json.Unmarshal(data, &shell) == nil {
+		if shell == "" {
+			return nil, nil
+		}
+		return []string{"/bin/sh", "-c", shell}, nil
+	}
+
+	err = json.Unmarshal(data, &command)
+	if err != nil {
+		return nil, fmt.Errorf("command must be string or array of strings: %w", err)
+	}
+	return command, nil
+}
+
+// Find returns path of devcontainer.json in the dir at one of the locations other tools look at.
+func Find(dir string) (path string, err error) {
+	for _, candidate := range []string{
+		filepath.Join(dir, ".devcontainer", "devcontainer.json"),
+		filepath.Join(dir, ".devcontainer.json"),
+	} {
+		if fp.IsFile(candidate) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("devcontainer.json not found in '%s'", dir)
+}
+
+func LoadFile(path string) (config *Config, err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read devcontainer file '%s': %w", path, err)
+	}
+
+	config, err = Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse devcontainer file '%s': %w", path, err)
+	}
+	return config, nil
+}
+
+func Parse(data []byte) (config *Config, err error) {
+	data = standardize(data)
+
+	var keys map[string]json.RawMessage
+	err = json.Unmarshal(data, &keys)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal devcontainer: %w", err)
+	}
+
+	config = &Config{}
+	err = json.Unmarshal(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal devcontainer: %w", err)
+	}
+
+	for key := range keys {
+		if !slices.Contains(supportedKeys, key) {
+			config.UnsupportedKeys = append(config.UnsupportedKeys, key)
+		}
+	}
+	sort.Strings(config.UnsupportedKeys)
+	return config, nil
+}
@@ -0,0 +1,151 @@
+package devcontainer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStandardize(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "line comment", input: "{\"a\": 1 // one\n}", expected: `{"a": 1}`},
+		{name: "block comment", input: `{/* a: 0, */ "a": 1}`, expected: `{"a": 1}`},
+		{name: "trailing comma in object", input: `{"a": 1,}`, expected: `{"a": 1}`},
+		{name: "trailing comma in array", input: `{"a": [1, 2, ],}`, expected: `{"a": [1, 2]}`},
+		{name: "trailing comma before comment", input: "{\"a\": 1, // one\n}", expected: `{"a": 1}`},
+		{name: "comment in string", input: `{"a": "http://x/*y*/"}`, expected: `{"a": "http://x/*y*/"}`},
+		{name: "escaped quote in string", input: `{"a": "say \"//\", ok", "b": 2,}`, expected: `{"a": "say \"//\", ok", "b": 2}`},
+		{name: "escaped backslash in string", input: `{"a": "c:\\", "b": "// x",}`, expected: `{"a": "c:\\", "b": "// x"}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var actual, expected any
+			err := json.Unmarshal(standardize([]byte(c.input)), &actual)
+			if err != nil {
+				t.Fatalf("standardized %q is not JSON: %s", c.input, err)
+			}
+			err = json.Unmarshal([]byte(c.expected), &expected)
+			if err != nil {
+				t.Fatalf("expected %q is not JSON: %s", c.expected, err)
+			}
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("expected %v, got %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestUnmarshalMount(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected Mount
+	}{
+		{
+			name:     "flag value",
+			input:    `"source=/a,target=/b,type=bind,readonly"`,
+			expected: Mount{Type: "bind", Source: "/a", Target: "/b", ReadOnly: true},
+		},
+		{
+			name:     "flag value aliases",
+			input:    `"type=volume,src=cache,dst=/cache,ro=false,consistency=cached"`,
+			expected: Mount{Type: "volume", Source: "cache", Target: "/cache", UnsupportedOptions: []string{"consistency=cached"}},
+		},
+		{
+			name:     "object",
+			input:    `{"type": "bind", "source": "/a", "target": "/b", "readonly": true}`,
+			expected: Mount{Type: "bind", Source: "/a", Target: "/b", ReadOnly: true},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var actual Mount
+			err := json.Unmarshal([]byte(c.input), &actual)
+			if err != nil {
+				t.Fatalf("cannot unmarshal mount: %s", err)
+			}
+			if !reflect.DeepEqual(c.expected, actual) {
+				t.Errorf("expected %+v, got %+v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestUnmarshalPort(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		expected    Port
+		expectError bool
+	}{
+		{name: "number", input: `8080`, expected: Port{Port: 8080}},
+		{name: "string", input: `"8080"`, expected: Port{Port: 8080}},
+		{name: "host and port", input: `"db:5432"`, expected: Port{Host: "db", Port: 5432}},
+		{name: "invalid", input: `"db:port"`, expectError: true},
+		{name: "object", input: `{}`, expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var actual Port
+			err := json.Unmarshal([]byte(c.input), &actual)
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot unmarshal port: %s", err)
+			}
+			if actual != c.expected {
+				t.Errorf("expected %+v, got %+v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestUnmarshalCommand(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       string
+		expected    [][]string
+		expectError bool
+	}{
+		{name: "shell string", input: `"make && make test"`, expected: [][]string{{"/bin/sh", "-c", "make && make test"}}},
+		{name: "empty string", input: `""`},
+		{name: "argv", input: `["git", "status"]`, expected: [][]string{{"git", "status"}}},
+		{
+			name:     "object ordered by name",
+			input:    `{"server": "npm start", "deps": ["npm", "ci"]}`,
+			expected: [][]string{{"npm", "ci"}, {"/bin/sh", "-c", "npm start"}},
+		},
+		{name: "invalid object command", input: `{"a": 1}`, expectError: true},
+		{name: "number", input: `1`, expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var actual Command
+			err := json.Unmarshal([]byte(c.input), &actual)
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot unmarshal command: %s", err)
+			}
+			if !reflect.DeepEqual(c.expected, actual.Commands) {
+				t.Errorf("expected %v, got %v", c.expected, actual.Commands)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package devcontainer
+
+// standardize turns JSON with comments and trailing commas, the format of devcontainer.json,
+// into standard JSON. Comments are replaced by spaces, so offsets of syntax errors are kept.
+func standardize(data []byte) []byte {
+	result := make([]byte, len(data))
+	copy(result, data)
+
+	// Index of the last comma outside of strings and comments which is not followed by a value yet.
+	pendingComma := -1
+	for i := 0; i < len(result); i++ {
+		switch c := result[i]; {
+		case c == '"':
+			pendingComma = -1
+			for i++; i < len(result) && result[i] != '"'; i++ {
+				if result[i] == '\\' {
+					i++
+				}
+			}
+		case c == '/' && i+1 < len(result) && result[i+1] == '/':
+			for ; i < len(result) && result[i] != '\n'; i++ {
+				result[i] = ' '
+			}
+		case c == '/' && i+1 < len(result) && result[i+1] == '*':
+			for ; i < len(result) && !(result[i] == '*' && i+1 < len(result) && result[i+1] == '/'); i++ {
+				if result[i] != '\n' {
+					result[i] = ' '
+				}
+			}
+			if i < len(result) {
+				result[i] = ' '
+				result[i+1] = ' '
+				i++
+			}
+		case c == ',':
+			pendingComma = i
+		case c == '}' || c == ']':
+			if pendingComma >= 0 {
+				result[pendingComma] = ' '
+			}
+			pendingComma = -1
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+		default:
+			pendingComma = -1
+		}
+	}
+	return result
+}
@@ -0,0 +1,289 @@
+package devcontainer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/hooks"
+	"dev-runner/pkg/dev/manifest"
+	"dev-runner/pkg/dev/manifest/hub"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+var variablePattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// forwardPortsAddress is the host address forwarded ports are bound to, devcontainer forwards them to localhost only.
+const forwardPortsAddress = "127.0.0.1"
+
+// ToManifest translates config into dev image manifest, devcontainer variables are resolved
+// against hostWorkDir. Settings which have no counterpart in the manifest are returned as unsupported.
+func ToManifest(config *Config, hostWorkDir string) (m *hub.Manifest, unsupported []string, err error) {
+	for _, key := range config.UnsupportedKeys {
+		unsupported = append(unsupported, fmt.Sprintf("key '%s'", key))
+	}
+	translator := variableTranslator{hostWorkDir: hostWorkDir}
+	spec := &hub.Spec{}
+
+	for _, mount := range config.Mounts {
+		var mountPoint *hub.MountPoint
+		mountPoint, err = getMountPoint(mount, &translator)
+		if err != nil {
+			return nil, nil, err
+		}
+		spec.MountPoints = append(spec.MountPoints, mountPoint)
+		for _, option := range mount.UnsupportedOptions {
+			unsupported = append(unsupported, fmt.Sprintf("mounts option '%s'", option))
+		}
+	}
+
+	names := make([]string, 0, len(config.ContainerEnv))
+	for name := range config.ContainerEnv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		spec.EnvironmentVariables = append(
+			spec.EnvironmentVariables,
+			&hub.EnvironmentVariable{
+				Name:  proto.String(name),
+				Value: proto.String(translator.translate(config.ContainerEnv[name])),
+			},
+		)
+	}
+
+	for _, port := range config.ForwardPorts {
+		if port.Host != "" && port.Host != "localhost" {
+			unsupported = append(unsupported, fmt.Sprintf("forwardPorts '%s:%d'", port.Host, port.Port))
+			continue
+		}
+		spec.PortBindings = append(
+			spec.PortBindings,
+			&hub.PortBinding{
+				HostPort:      proto.Int32(int32(port.Port)),
+				ContainerPort: proto.Int32(int32(port.Port)),
+				HostAddress:   proto.String(forwardPortsAddress),
+			},
+		)
+	}
+
+	var unsupportedRunArgs []string
+	spec.Resources, spec.Security, unsupportedRunArgs, err = parseRunArgs(config.RunArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, arg := range unsupportedRunArgs {
+		unsupported = append(unsupported, fmt.Sprintf("runArgs '%s'", arg))
+	}
+
+	lifecycle := &hub.Lifecycle{
+		PostCreate: getHooks(config.PostCreateCommand),
+		PostStart:  getHooks(config.PostStartCommand),
+	}
+	if len(lifecycle.GetPostCreate()) != 0 || len(lifecycle.GetPostStart()) != 0 {
+		spec.Lifecycle = lifecycle
+	}
+
+	for _, variable := range translator.unsupported {
+		unsupported = append(unsupported, fmt.Sprintf("variable '${%s}'", variable))
+	}
+
+	m = &hub.Manifest{
+		Kind:    proto.String(manifest.Kind),
+		Version: proto.String(manifest.Version),
+		Spec:    spec,
+	}
+	return m, unsupported, nil
+}
+
+func getMountPoint(mount Mount, translator *variableTranslator) (mountPoint *hub.MountPoint, err error) {
+	if mount.Target == "" {
+		return nil, fmt.Errorf("mount must have target")
+	}
+
+	mountPoint = &hub.MountPoint{
+		ContainerPath: proto.String(translator.translate(mount.Target)),
+	}
+	if mount.ReadOnly {
+		mountPoint.ReadOnly = proto.Bool(true)
+	}
+
+	switch mount.Type {
+	case "volume":
+		if mount.Source == "" {
+			return nil, fmt.Errorf("volume mount '%s' must have source", mount.Target)
+		}
+		mountPoint.Type = hub.MountPoint_Volume.Enum()
+		mountPoint.VolumeName = proto.String(translator.translate(mount.Source))
+	case "tmpfs":
+		mountPoint.Type = hub.MountPoint_Tmpfs.Enum()
+	case "bind", "":
+		if mount.Source == "" {
+			return nil, fmt.Errorf("bind mount '%s' must have source", mount.Target)
+		}
+		hostPath := translator.translate(mount.Source)
+		// Other devcontainer tools fail on missing source as docker does.
+		mountPoint.HostPath = proto.String(hostPath)
+		mountPoint.MustExists = proto.Bool(true)
+		mountPoint.Type = hub.MountPoint_Directory.Enum()
+		if fp.IsFile(os.ExpandEnv(hostPath)) {
+			mountPoint.Type = hub.MountPoint_File.Enum()
+		}
+	default:
+		return nil, fmt.Errorf("mount '%s' type '%s' is not supported", mount.Target, mount.Type)
+	}
+	return mountPoint, nil
+}
+
+func getHooks(command Command) (hooksList []*hub.Hook) {
+	for _, item := range command.Commands {
+		hooksList = append(hooksList, &hub.Hook{Command: item})
+	}
+	return hooksList
+}
+
+// parseRunArgs picks resources and security settings out of docker run arguments.
+func parseRunArgs(args []string) (resources *hub.Resources, security *hub.Security, unsupported []string, err error) {
+	resources = &hub.Resources{}
+	security = &hub.Security{}
+
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		if !hasValue && isRunArgWithValue(name) {
+			if i+1 == len(args) {
+				return nil, nil, nil, fmt.Errorf("runArgs '%s' must have value", name)
+			}
+			i++
+			value = args[i]
+		}
+
+		switch name {
+		case "--cpus":
+			var cpus float64
+			cpus, err = strconv.ParseFloat(value, 64)
+			if err != nil || cpus < 0 {
+				return nil, nil, nil, fmt.Errorf("runArgs '%s' must be positive number", name)
+			}
+			resources.Cpus = proto.Float64(cpus)
+		case "--memory", "-m":
+			resources.Memory, err = getSize(name, value)
+		case "--memory-swap":
+			resources.MemorySwap, err = getSize(name, value)
+		case "--shm-size":
+			resources.ShmSize, err = getSize(name, value)
+		case "--pids-limit":
+			var pidsLimit int64
+			pidsLimit, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("runArgs '%s' must be number", name)
+			}
+			resources.PidsLimit = proto.Int64(pidsLimit)
+		case "--ulimit":
+			var ulimit management.Ulimit
+			ulimit, err = management.ParseUlimit(value)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("runArgs '%s' must be valid: %w", name, err)
+			}
+			resources.Ulimits = append(
+				resources.Ulimits,
+				&hub.Ulimit{
+					Name: proto.String(ulimit.Name),
+					Soft: proto.Int64(ulimit.Soft),
+					Hard: proto.Int64(ulimit.Hard),
+				},
+			)
+		case "--cap-add":
+			security.CapAdd = append(security.CapAdd, value)
+		case "--cap-drop":
+			security.CapDrop = append(security.CapDrop, value)
+		case "--security-opt":
+			option, optionValue, _ := strings.Cut(value, "=")
+			switch {
+			case option == "seccomp":
+				security.SeccompProfile = proto.String(optionValue)
+			case value == "no-new-privileges" || value == "no-new-privileges:true" || value == "no-new-privileges=true":
+				security.NoNewPrivileges = proto.Bool(true)
+			default:
+				unsupported = append(unsupported, name+"="+value)
+			}
+		default:
+			if isRunArgWithValue(name) {
+				unsupported = append(unsupported, name+"="+value)
+			} else {
+				unsupported = append(unsupported, args[i])
+			}
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if proto.Size(resources) == 0 {
+		resources = nil
+	}
+	if proto.Size(security) == 0 {
+		security = nil
+	}
+	return resources, security, unsupported, nil
+}
+
+// isRunArgWithValue tells whether the docker run flag takes value, so the value may be the next argument.
+func isRunArgWithValue(name string) bool {
+	if !strings.HasPrefix(name, "-") {
+		return false
+	}
+	switch name {
+	case "--privileged", "--init", "--rm", "-d", "--detach", "-i", "--interactive", "-t", "--tty",
+		"--read-only", "--no-healthcheck", "--oom-kill-disable", "--publish-all", "-P":
+		return false
+	}
+	return true
+}
+
+func getSize(name string, value string) (size *string, err error) {
+	_, err = management.ParseSize(value)
+	if err != nil {
+		return nil, fmt.Errorf("runArgs '%s' must be valid size: %w", name, err)
+	}
+	return proto.String(value), nil
+}
+
+// variableTranslator rewrites devcontainer variables into manifest ones.
+type variableTranslator struct {
+	hostWorkDir string
+	unsupported []string
+}
+
+func (t *variableTranslator) translate(value string) string {
+	return variablePattern.ReplaceAllStringFunc(value, func(match string) string {
+		variable := match[2 : len(match)-1]
+		switch {
+		case variable == "localWorkspaceFolder":
+			return t.hostWorkDir
+		case variable == "localWorkspaceFolderBasename":
+			return filepath.Base(t.hostWorkDir)
+		case variable == "containerWorkspaceFolder":
+			return hooks.DefaultWorkDir
+		case variable == "containerWorkspaceFolderBasename":
+			return filepath.Base(hooks.DefaultWorkDir)
+		case strings.HasPrefix(variable, "localEnv:"):
+			name, defaultValue, hasDefault := strings.Cut(strings.TrimPrefix(variable, "localEnv:"), ":")
+			if hasDefault {
+				if _, ok := os.LookupEnv(name); !ok {
+					return defaultValue
+				}
+			}
+			return "${" + name + "}"
+		}
+		t.unsupported = append(t.unsupported, variable)
+		return match
+	})
+}
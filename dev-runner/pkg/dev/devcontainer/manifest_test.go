@@ -0,0 +1,98 @@
+package devcontainer
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"dev-runner/pkg/dev/manifest/hub"
+)
+
+func TestParseRunArgs(t *testing.T) {
+	cases := []struct {
+		name                string
+		args                []string
+		expectedResources   *hub.Resources
+		expectedSecurity    *hub.Security
+		expectedUnsupported []string
+		expectError         bool
+	}{
+		{
+			name:              "flag and value apart",
+			args:              []string{"--cpus", "2", "--memory", "4g", "--ulimit", "nofile=1024:2048"},
+			expectedResources: &hub.Resources{Cpus: proto.Float64(2), Memory: proto.String("4g"), Ulimits: []*hub.Ulimit{{Name: proto.String("nofile"), Soft: proto.Int64(1024), Hard: proto.Int64(2048)}}},
+		},
+		{
+			name:              "flag and value joined",
+			args:              []string{"--cpus=2", "--memory=4g", "--ulimit=nofile=1024:2048"},
+			expectedResources: &hub.Resources{Cpus: proto.Float64(2), Memory: proto.String("4g"), Ulimits: []*hub.Ulimit{{Name: proto.String("nofile"), Soft: proto.Int64(1024), Hard: proto.Int64(2048)}}},
+		},
+		{
+			name:             "security",
+			args:             []string{"--cap-add", "SYS_PTRACE", "--cap-drop=ALL", "--security-opt", "seccomp=unconfined", "--security-opt=no-new-privileges"},
+			expectedSecurity: &hub.Security{CapAdd: []string{"SYS_PTRACE"}, CapDrop: []string{"ALL"}, SeccompProfile: proto.String("unconfined"), NoNewPrivileges: proto.Bool(true)},
+		},
+		{
+			name:                "unsupported",
+			args:                []string{"--init", "--network", "host", "--security-opt=label=disable", "--privileged"},
+			expectedUnsupported: []string{"--init", "--network=host", "--security-opt=label=disable", "--privileged"},
+		},
+		{name: "missing value", args: []string{"--memory"}, expectError: true},
+		{name: "invalid cpus", args: []string{"--cpus", "many"}, expectError: true},
+		{name: "invalid size", args: []string{"--shm-size=big"}, expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resources, security, unsupported, err := parseRunArgs(c.args)
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected error for %v", c.args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot parse run args: %s", err)
+			}
+			if !proto.Equal(c.expectedResources, resources) {
+				t.Errorf("resources: expected %v, got %v", c.expectedResources, resources)
+			}
+			if !proto.Equal(c.expectedSecurity, security) {
+				t.Errorf("security: expected %v, got %v", c.expectedSecurity, security)
+			}
+			if !reflect.DeepEqual(c.expectedUnsupported, unsupported) {
+				t.Errorf("unsupported: expected %v, got %v", c.expectedUnsupported, unsupported)
+			}
+		})
+	}
+}
+
+func TestToManifestForwardsPortsToLocalhost(t *testing.T) {
+	config, err := Parse([]byte(`{"forwardPorts": [3000, "localhost:8080", "db:5432"]}`))
+	if err != nil {
+		t.Fatalf("cannot parse devcontainer: %s", err)
+	}
+
+	m, unsupported, err := ToManifest(config, t.TempDir())
+	if err != nil {
+		t.Fatalf("cannot translate devcontainer: %s", err)
+	}
+
+	expected := []*hub.PortBinding{
+		{HostPort: proto.Int32(3000), ContainerPort: proto.Int32(3000), HostAddress: proto.String("127.0.0.1")},
+		{HostPort: proto.Int32(8080), ContainerPort: proto.Int32(8080), HostAddress: proto.String("127.0.0.1")},
+	}
+	actual := m.GetSpec().GetPortBindings()
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if !proto.Equal(expected[i], actual[i]) {
+			t.Errorf("port binding #%d: expected %v, got %v", i, expected[i], actual[i])
+		}
+	}
+	if !reflect.DeepEqual(unsupported, []string{"forwardPorts 'db:5432'"}) {
+		t.Errorf("expected port of other host to be unsupported, got %v", unsupported)
+	}
+}
@@ -0,0 +1,158 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/manifest/hub"
+	"dev-runner/pkg/dev/state"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+type Phase string
+
+const (
+	PostCreate Phase = "post-create"
+	PostStart  Phase = "post-start"
+	PreStop    Phase = "pre-stop"
+)
+
+// DefaultWorkDir is the dir hooks run in unless they set another one.
+const DefaultWorkDir = "/work"
+
+func GetHooks(spec *hub.Spec, phase Phase) []*hub.Hook {
+	lifecycle := spec.GetLifecycle()
+	switch phase {
+	case PostCreate:
+		return lifecycle.GetPostCreate()
+	case PostStart:
+		return lifecycle.GetPostStart()
+	case PreStop:
+		return lifecycle.GetPreStop()
+	}
+	return nil
+}
+
+// LogPath returns path of the file hooks output of the container is recorded to.
+func LogPath(containerName string) (path string, err error) {
+	var dir string
+	dir, err = state.ContainerDir(containerName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "hooks.log"), nil
+}
+
+type Runner struct {
+	Manager       management.ContainerManager
+	ContainerName string
+	// User runs hooks which do not set user.
+	User   string
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Run runs hooks of the phase one by one, hook output is streamed to stdout and stderr
+// and recorded to the container hooks log.
+func (r Runner) Run(ctx context.Context, phase Phase, hooks []*hub.Hook) (err error) {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	var logPath string
+	logPath, err = LogPath(r.ContainerName)
+	if err != nil {
+		return err
+	}
+	err = fp.MakePaths(filepath.Dir(logPath))
+	if err != nil {
+		return fmt.Errorf("cannot create hooks log dir: %w", err)
+	}
+
+	var logFile *os.File
+	logFile, err = os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot open hooks log '%s': %w", logPath, err)
+	}
+	defer func() { _ = logFile.Close() }()
+
+	stdout := io.MultiWriter(r.Stdout, logFile)
+	stderr := io.MultiWriter(r.Stderr, logFile)
+
+	for i, hook := range hooks {
+		_, _ = fmt.Fprintf(logFile, "%s %s hook #%d: %s\n",
+			time.Now().Format(time.RFC3339), phase, i+1, strings.Join(hook.GetCommand(), " "))
+		log.Printf("running %s hook #%d\n", phase, i+1)
+
+		err = r.runHook(ctx, hook, stdout, stderr)
+		if err == nil {
+			continue
+		}
+
+		err = fmt.Errorf("%s hook #%d failed: %w", phase, i+1, err)
+		_, _ = fmt.Fprintln(logFile, err.Error())
+		if hook.GetFailurePolicy() == hub.Hook_Ignore {
+			log.Printf("%s, ignored\n", err.Error())
+			continue
+		}
+		return err
+	}
+	return nil
+}
+
+func (r Runner) runHook(ctx context.Context, hook *hub.Hook, stdout io.Writer, stderr io.Writer) (err error) {
+	if len(hook.GetCommand()) == 0 {
+		return fmt.Errorf("hook command must be set")
+	}
+
+	if hook.GetTimeoutSeconds() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(hook.GetTimeoutSeconds())*time.Second)
+		defer cancel()
+	}
+
+	user := hook.GetUser()
+	if user == "" {
+		user = r.User
+	}
+	workDir := hook.GetWorkDir()
+	if workDir == "" {
+		workDir = DefaultWorkDir
+	}
+
+	var exitCode int
+	exitCode, err = r.Manager.ExecInContainer(
+		ctx,
+		r.ContainerName,
+		management.ExecOptions{
+			Cmd:     hook.GetCommand(),
+			User:    user,
+			WorkDir: workDir,
+		},
+		nil,
+		stdout,
+		stderr,
+	)
+	if hook.GetTimeoutSeconds() > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %ds: %w", hook.GetTimeoutSeconds(), ctx.Err())
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("exit code %d", exitCode)
+	}
+	return nil
+}
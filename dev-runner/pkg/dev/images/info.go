@@ -0,0 +1,85 @@
+package images
+
+import (
+	"strings"
+	"time"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/manifest"
+
+	devlabels "dev-runner/pkg/dev/labels"
+)
+
+// ImageInfo describes dev image by its labels.
+type ImageInfo struct {
+	Id            string
+	Tags          []string
+	Ide           string
+	Version       string
+	Compilers     []string
+	DistroVersion string
+	DistroVariant string
+	HasManifest   bool
+	Created       time.Time
+	Size          int64
+}
+
+// Distro returns distro version with variant, e.g. 'debian12-slim'.
+func (i ImageInfo) Distro() string {
+	if i.DistroVariant == "" {
+		return i.DistroVersion
+	}
+	return i.DistroVersion + "-" + i.DistroVariant
+}
+
+// ParseImageInfo returns false if the image is not dev image, that is it has no IDE label.
+func ParseImageInfo(summary management.ImageSummary) (info ImageInfo, ok bool) {
+	info.Ide, ok = summary.GetLabel(devlabels.Ide)
+	if !ok {
+		return ImageInfo{}, false
+	}
+
+	info.Id = summary.Id
+	info.Tags = summary.Tags
+	info.Created = summary.Created
+	info.Size = summary.Size
+	info.Version, _ = summary.GetLabel(devlabels.Version)
+	info.DistroVersion, _ = summary.GetLabel(devlabels.DistroVersion)
+	info.DistroVariant, _ = summary.GetLabel(devlabels.DistroVariant)
+	_, info.HasManifest = summary.GetLabel(manifest.ImageLabel)
+
+	compilers, _ := summary.GetLabel(devlabels.Compilers)
+	info.Compilers = strings.FieldsFunc(compilers, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	return info, true
+}
+
+// Filter selects dev images, empty fields match any image. Values are compared case-insensitively.
+type Filter struct {
+	Ide      string
+	Version  string
+	Compiler string
+	Distro   string
+}
+
+func (f Filter) Match(info ImageInfo) bool {
+	if f.Ide != "" && !strings.EqualFold(f.Ide, info.Ide) {
+		return false
+	}
+	if f.Version != "" && !strings.EqualFold(f.Version, info.Version) {
+		return false
+	}
+	if f.Distro != "" && !strings.EqualFold(f.Distro, info.DistroVersion) && !strings.EqualFold(f.Distro, info.Distro()) {
+		return false
+	}
+	if f.Compiler != "" {
+		for _, compiler := range info.Compilers {
+			if strings.EqualFold(f.Compiler, compiler) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
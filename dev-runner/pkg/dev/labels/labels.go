@@ -0,0 +1,18 @@
+package labels
+
+// Container labels set by dev-runner to find dev containers back.
+const (
+	ImageTag = "dev.containers.runner.image"
+	WorkDir  = "dev.containers.runner.workdir"
+	SshPort  = "dev.containers.runner.ssh-port"
+	User     = "dev.containers.runner.user"
+)
+
+// Image labels set by dev images Dockerfiles to describe them.
+const (
+	Ide           = "dev.containers.ide"
+	Version       = "dev.containers.version"
+	Compilers     = "dev.containers.compilers"
+	DistroVersion = "dev.containers.distro.version"
+	DistroVariant = "dev.containers.distro.variant"
+)
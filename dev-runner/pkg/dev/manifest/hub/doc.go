@@ -1 +1,4 @@
+// Package hub contains the dev-runner manifest schema generated from manifest.proto.
 package hub
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative manifest.proto
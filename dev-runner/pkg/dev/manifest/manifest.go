@@ -0,0 +1,341 @@
+package manifest
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	"dev-runner/pkg/conainer/management"
+	"dev-runner/pkg/dev/manifest/hub"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+const (
+	Kind    = "dev-runner-manifest"
+	Version = "v1"
+
+	// ImageLabel is the image label holding base64 encoded manifest textproto.
+	ImageLabel = "dev.containers.manifest"
+)
+
+func LoadFile(path string) (manifest *hub.Manifest, err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read manifest file '%s': %w", path, err)
+	}
+
+	manifest, err = Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse manifest file '%s': %w", path, err)
+	}
+	return manifest, nil
+}
+
+func Parse(data []byte) (manifest *hub.Manifest, err error) {
+	manifest = &hub.Manifest{}
+	err = prototext.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal manifest: %w", err)
+	}
+
+	if manifest.GetKind() != Kind {
+		return nil, fmt.Errorf("unsupported manifest kind '%s', expected '%s'", manifest.GetKind(), Kind)
+	}
+	if manifest.GetVersion() != Version {
+		return nil, fmt.Errorf("unsupported manifest version '%s', expected '%s'", manifest.GetVersion(), Version)
+	}
+	return manifest, nil
+}
+
+func FindInLabels(labels []management.Label) (manifest *hub.Manifest, err error) {
+	for _, label := range labels {
+		if label.Name != ImageLabel {
+			continue
+		}
+
+		var data []byte
+		data, err = base64.StdEncoding.DecodeString(label.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode label '%s': %w", ImageLabel, err)
+		}
+
+		manifest, err = Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse manifest from label '%s': %w", ImageLabel, err)
+		}
+		return manifest, nil
+	}
+	return nil, nil
+}
+
+// ToLabel encodes manifest into label, so it can be found back by FindInLabels.
+func ToLabel(manifest *hub.Manifest) (label management.Label, err error) {
+	var data []byte
+	data, err = prototext.Marshal(manifest)
+	if err != nil {
+		return management.Label{}, fmt.Errorf("cannot marshal manifest: %w", err)
+	}
+	return management.Label{
+		Name:  ImageLabel,
+		Value: base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
+
+// Merge merges overlay into manifest. Mount points with the same container path, environment
+// variables with the same name and port bindings with the same container port are kept once,
+// the overlay ones win in place of the manifest ones.
+func Merge(manifest *hub.Manifest, overlay *hub.Manifest) {
+	proto.Merge(manifest, overlay)
+
+	spec := manifest.GetSpec()
+	if spec == nil {
+		return
+	}
+	spec.MountPoints = dedupe(spec.GetMountPoints(), (*hub.MountPoint).GetContainerPath)
+	spec.EnvironmentVariables = dedupe(spec.GetEnvironmentVariables(), (*hub.EnvironmentVariable).GetName)
+	spec.PortBindings = dedupe(spec.GetPortBindings(), func(item *hub.PortBinding) string {
+		return strconv.Itoa(int(item.GetContainerPort()))
+	})
+}
+
+// dedupe keeps the last item of each key at the position of the first one.
+func dedupe[T any](items []T, getKey func(T) string) (result []T) {
+	positions := make(map[string]int, len(items))
+	for _, item := range items {
+		key := getKey(item)
+		if i, ok := positions[key]; ok {
+			result[i] = item
+			continue
+		}
+		positions[key] = len(result)
+		result = append(result, item)
+	}
+	return result
+}
+
+// Variables are substituted into manifest values before the process environment.
+type Variables map[string]string
+
+func (v Variables) Expand(value string) string {
+	return os.Expand(value, func(name string) string {
+		if value, ok := v[name]; ok {
+			return value
+		}
+		return os.Getenv(name)
+	})
+}
+
+func GetMountPoints(spec *hub.Spec, vars Variables) (mountPoints []management.MountPoint, err error) {
+	for _, item := range spec.GetMountPoints() {
+		containerPath := vars.Expand(item.GetContainerPath())
+
+		switch item.GetType() {
+		case hub.MountPoint_Tmpfs:
+			var mountPoint management.MountPoint
+			mountPoint, err = getTmpfsMountPoint(item, containerPath)
+			if err != nil {
+				return nil, err
+			}
+			mountPoints = append(mountPoints, mountPoint)
+			continue
+		case hub.MountPoint_Volume:
+			volumeName := vars.Expand(item.GetVolumeName())
+			if volumeName == "" {
+				return nil, fmt.Errorf("mount point '%s' must have volume name", containerPath)
+			}
+			mountPoints = append(
+				mountPoints,
+				management.MountPoint{
+					Type:          management.MountVolume,
+					VolumeName:    volumeName,
+					ContainerPath: containerPath,
+					ReadOnly:      item.GetReadOnly(),
+				},
+			)
+			continue
+		}
+
+		hostPath := vars.Expand(item.GetHostPath())
+		if hostPath == "" {
+			return nil, fmt.Errorf("mount point '%s' must have host path", containerPath)
+		}
+
+		selinuxRelabel := item.GetSelinuxRelabel()
+		if selinuxRelabel != "" && selinuxRelabel != management.SELinuxRelabelShared && selinuxRelabel != management.SELinuxRelabelPrivate {
+			return nil, fmt.Errorf("mount point '%s' SELinux relabel must be '%s' or '%s'",
+				containerPath, management.SELinuxRelabelShared, management.SELinuxRelabelPrivate)
+		}
+
+		var exists bool
+		exists, err = prepareHostPath(item, hostPath)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+
+		mountPoints = append(
+			mountPoints,
+			management.MountPoint{
+				Type:           management.MountBind,
+				HostPath:       hostPath,
+				ContainerPath:  containerPath,
+				ReadOnly:       item.GetReadOnly(),
+				SELinuxRelabel: selinuxRelabel,
+			},
+		)
+	}
+	return mountPoints, nil
+}
+
+func GetEnvironmentVariables(spec *hub.Spec, vars Variables) (environmentVariables []management.EnvironmentVariable) {
+	for _, item := range spec.GetEnvironmentVariables() {
+		environmentVariables = append(
+			environmentVariables,
+			management.EnvironmentVariable{
+				Name:  item.GetName(),
+				Value: vars.Expand(item.GetValue()),
+			},
+		)
+	}
+	return environmentVariables
+}
+
+func GetPortBindings(spec *hub.Spec) (portBindings []management.PortBinding) {
+	for _, item := range spec.GetPortBindings() {
+		portBindings = append(
+			portBindings,
+			management.PortBinding{
+				ContainerPort: int(item.GetContainerPort()),
+				HostPort:      int(item.GetHostPort()),
+				HostAddress:   item.GetHostAddress(),
+			},
+		)
+	}
+	return portBindings
+}
+
+func GetResourceLimits(spec *hub.Spec) (limits management.ResourceLimits, err error) {
+	resources := spec.GetResources()
+	limits.Cpus = resources.GetCpus()
+	limits.PidsLimit = resources.GetPidsLimit()
+
+	limits.Memory, err = management.ParseSize(resources.GetMemory())
+	if err != nil {
+		return management.ResourceLimits{}, fmt.Errorf("cannot parse memory limit: %w", err)
+	}
+	limits.MemorySwap, err = management.ParseSize(resources.GetMemorySwap())
+	if err != nil {
+		return management.ResourceLimits{}, fmt.Errorf("cannot parse memory swap limit: %w", err)
+	}
+	limits.ShmSize, err = management.ParseSize(resources.GetShmSize())
+	if err != nil {
+		return management.ResourceLimits{}, fmt.Errorf("cannot parse shm size: %w", err)
+	}
+
+	for _, item := range resources.GetUlimits() {
+		limits.Ulimits = append(
+			limits.Ulimits,
+			management.Ulimit{
+				Name: item.GetName(),
+				Soft: item.GetSoft(),
+				Hard: item.GetHard(),
+			},
+		)
+	}
+	return limits, nil
+}
+
+func GetSecurityProfile(spec *hub.Spec) management.SecurityProfile {
+	return management.SecurityProfile(spec.GetSecurity().GetProfile())
+}
+
+// GetSecurityOptions returns security options set explicitly in manifest,
+// they are applied on top of the security profile.
+func GetSecurityOptions(spec *hub.Spec, vars Variables) management.SecurityOptions {
+	security := spec.GetSecurity()
+
+	seccompProfile := security.GetSeccompProfile()
+	if seccompProfile != management.SeccompUnconfined {
+		seccompProfile = vars.Expand(seccompProfile)
+	}
+
+	return management.SecurityOptions{
+		CapAdd:          security.GetCapAdd(),
+		CapDrop:         security.GetCapDrop(),
+		SeccompProfile:  seccompProfile,
+		NoNewPrivileges: security.GetNoNewPrivileges(),
+	}
+}
+
+func getTmpfsMountPoint(item *hub.MountPoint, containerPath string) (mountPoint management.MountPoint, err error) {
+	mountPoint = management.MountPoint{
+		Type:          management.MountTmpfs,
+		ContainerPath: containerPath,
+		ReadOnly:      item.GetReadOnly(),
+	}
+
+	mountPoint.TmpfsSize, err = management.ParseSize(item.GetTmpfsSize())
+	if err != nil {
+		return management.MountPoint{}, fmt.Errorf("cannot parse mount point '%s' tmpfs size: %w", containerPath, err)
+	}
+
+	if item.GetTmpfsMode() != "" {
+		var mode uint64
+		mode, err = strconv.ParseUint(item.GetTmpfsMode(), 8, 32)
+		if err != nil {
+			return management.MountPoint{}, fmt.Errorf("cannot parse mount point '%s' tmpfs mode: %w", containerPath, err)
+		}
+		mountPoint.TmpfsMode = os.FileMode(mode)
+	}
+	return mountPoint, nil
+}
+
+// prepareHostPath checks the host side of the mount point and creates it when requested.
+// It reports false when an optional path is missing and the mount point must be skipped.
+func prepareHostPath(item *hub.MountPoint, hostPath string) (exists bool, err error) {
+	switch item.GetType() {
+	case hub.MountPoint_Directory:
+		if fp.IsExists(hostPath) && !fp.IsDir(hostPath) {
+			return false, fmt.Errorf("mount point host path '%s' must be directory", hostPath)
+		}
+		if fp.IsDir(hostPath) {
+			return true, nil
+		}
+		if item.GetNeedCreate() {
+			err = fp.MakePaths(hostPath)
+			if err != nil {
+				return false, fmt.Errorf("cannot create required directory '%s': %w", hostPath, err)
+			}
+			return true, nil
+		}
+	case hub.MountPoint_File:
+		if fp.IsDir(hostPath) {
+			return false, fmt.Errorf("mount point host path '%s' must be file", hostPath)
+		}
+		if fp.IsFile(hostPath) {
+			return true, nil
+		}
+		if item.GetNeedCreate() {
+			err = fp.MakeFiles(hostPath)
+			if err != nil {
+				return false, fmt.Errorf("cannot create required file '%s': %w", hostPath, err)
+			}
+			return true, nil
+		}
+	default:
+		return false, fmt.Errorf("mount point type '%s' is not supported", item.GetType())
+	}
+
+	if item.GetMustExists() {
+		return false, fmt.Errorf("mount point host path '%s' must exists", hostPath)
+	}
+	return false, nil
+}
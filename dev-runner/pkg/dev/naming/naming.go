@@ -1,6 +1,8 @@
 package naming
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
 	"slices"
@@ -15,16 +17,71 @@ var validSymbols = []rune{
 
 const symbolForReplacement = '_'
 
+// workDirHashLength is the number of hex symbols of work dir hash used in names.
+const workDirHashLength = 8
+
 func GenContainerName(imageTag string, workDir string) string {
+	return fmt.Sprintf("%s_%s_%s",
+		GenImageName(imageTag),
+		sanitize(filepath.Base(ResolveWorkDir(workDir))),
+		GenWorkDirHash(workDir),
+	)
+}
+
+func GenImageName(imageTag string) string {
+	return sanitize(imageTag)
+}
+
+func GenDevHomeDirName(imageTag string, workDir string) string {
+	return fmt.Sprintf(".%s--%s--%s--dev-home",
+		sanitize(filepath.Base(ResolveWorkDir(workDir))),
+		GenImageName(imageTag),
+		GenWorkDirHash(workDir),
+	)
+}
+
+// GenWorkDirHash returns short stable hash of the absolute, symlink-resolved work dir.
+func GenWorkDirHash(workDir string) string {
+	sum := sha256.Sum256([]byte(ResolveWorkDir(workDir)))
+	return hex.EncodeToString(sum[:])[:workDirHashLength]
+}
+
+// ResolveWorkDir makes work dir absolute and resolves symlinks,
+// so the same directory gives the same names whatever path is used to reach it.
+func ResolveWorkDir(workDir string) string {
+	path, err := filepath.Abs(workDir)
+	if err != nil {
+		path = filepath.Clean(workDir)
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return path
+	}
+	return resolved
+}
+
+// GenLegacyContainerName returns container name of the previous naming scheme
+// which is used only to find containers created by older versions.
+func GenLegacyContainerName(imageTag string, workDir string) string {
 	return fmt.Sprintf("%s_%s",
 		GenImageName(imageTag),
 		filepath.Base(workDir),
 	)
 }
 
-func GenImageName(imageTag string) string {
+// GenLegacyDevHomeDirName returns dev home dir name of the previous naming scheme
+// which is used only to migrate dev home dirs created by older versions.
+func GenLegacyDevHomeDirName(imageTag string, workDir string) string {
+	return fmt.Sprintf(".%s--%s--dev-home",
+		filepath.Base(workDir),
+		GenImageName(imageTag),
+	)
+}
+
+func sanitize(value string) string {
 	b := strings.Builder{}
-	for _, ch := range strings.ToLower(imageTag) {
+	for _, ch := range strings.ToLower(value) {
 		if slices.Contains(validSymbols, ch) {
 			b.WriteRune(ch)
 			continue
@@ -33,10 +90,3 @@ func GenImageName(imageTag string) string {
 	}
 	return b.String()
 }
-
-func GenDevHomeDirName(imageTag string, workDir string) string {
-	return fmt.Sprintf(".%s--%s--dev-home",
-		filepath.Base(workDir),
-		GenImageName(imageTag),
-	)
-}
@@ -0,0 +1,46 @@
+package naming
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNamesDoNotDependOnWorkDirPath(t *testing.T) {
+	root := t.TempDir()
+	workDir := filepath.Join(root, "Project")
+	err := os.Mkdir(workDir, 0o755)
+	if err != nil {
+		t.Fatalf("cannot create work dir: %s", err)
+	}
+	link := filepath.Join(root, "link")
+	err = os.Symlink(workDir, link)
+	if err != nil {
+		t.Fatalf("cannot create work dir link: %s", err)
+	}
+	previousDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("cannot get current dir: %s", err)
+	}
+	err = os.Chdir(workDir)
+	if err != nil {
+		t.Fatalf("cannot change current dir: %s", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(previousDir) })
+
+	const imageTag = "dev-test:1"
+	expectedContainerName := GenContainerName(imageTag, workDir)
+	expectedDevHomeDirName := GenDevHomeDirName(imageTag, workDir)
+	for _, path := range []string{".", link, workDir + "/"} {
+		if name := GenContainerName(imageTag, path); name != expectedContainerName {
+			t.Errorf("container name for '%s': expected '%s', got '%s'", path, expectedContainerName, name)
+		}
+		if name := GenDevHomeDirName(imageTag, path); name != expectedDevHomeDirName {
+			t.Errorf("dev home dir name for '%s': expected '%s', got '%s'", path, expectedDevHomeDirName, name)
+		}
+	}
+	if !strings.HasPrefix(expectedContainerName, "dev-test_1_project_") {
+		t.Errorf("container name '%s' must keep work dir name", expectedContainerName)
+	}
+}
@@ -0,0 +1,175 @@
+package ports
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"dev-runner/pkg/dev/state"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+const (
+	// FirstSshPort is the first port tried when free SSH port is allocated.
+	FirstSshPort = 2221
+	lastSshPort  = 2999
+
+	registryFileName = "ports.json"
+)
+
+// Registry keeps SSH ports allocated for dev containers keyed by container name.
+type Registry struct {
+	path  string
+	Ports map[string]int `json:"ports"`
+}
+
+// DefaultRegistryPath returns registry file path in the dev-runner state dir.
+func DefaultRegistryPath() (path string, err error) {
+	var stateDir string
+	stateDir, err = state.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, registryFileName), nil
+}
+
+// LoadRegistry reads the registry, the registry file is locked for reading meanwhile.
+func LoadRegistry(path string) (registry *Registry, err error) {
+	var unlock func()
+	unlock, err = fp.Lock(path, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return load(path)
+}
+
+func load(path string) (registry *Registry, err error) {
+	registry = &Registry{path: path, Ports: map[string]int{}}
+
+	var data []byte
+	data, err = os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ports registry '%s': %w", path, err)
+	}
+
+	err = json.Unmarshal(data, registry)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse ports registry '%s': %w", path, err)
+	}
+	if registry.Ports == nil {
+		registry.Ports = map[string]int{}
+	}
+	return registry, nil
+}
+
+func LoadDefaultRegistry() (registry *Registry, err error) {
+	var path string
+	path, err = DefaultRegistryPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadRegistry(path)
+}
+
+// UpdateRegistry reads the registry, applies update to it and saves it. The registry file is locked
+// meanwhile, so concurrent updates do not overwrite each other.
+func UpdateRegistry(path string, update func(registry *Registry) error) (err error) {
+	var unlock func()
+	unlock, err = fp.Lock(path, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var registry *Registry
+	registry, err = load(path)
+	if err != nil {
+		return err
+	}
+
+	err = update(registry)
+	if err != nil {
+		return err
+	}
+	return registry.save()
+}
+
+func UpdateDefaultRegistry(update func(registry *Registry) error) (err error) {
+	var path string
+	path, err = DefaultRegistryPath()
+	if err != nil {
+		return err
+	}
+	return UpdateRegistry(path, update)
+}
+
+func (r *Registry) Lookup(containerName string) (port int, ok bool) {
+	port, ok = r.Ports[containerName]
+	return port, ok
+}
+
+func (r *Registry) Set(containerName string, port int) {
+	r.Ports[containerName] = port
+}
+
+func (r *Registry) Delete(containerName string) {
+	delete(r.Ports, containerName)
+}
+
+// Allocate returns the port registered for the container if it is still free,
+// otherwise the first free port not registered for other containers.
+func (r *Registry) Allocate(containerName string) (port int, err error) {
+	if registered, ok := r.Lookup(containerName); ok && IsPortFree(registered) {
+		return registered, nil
+	}
+
+	used := make(map[int]bool, len(r.Ports))
+	for name, registered := range r.Ports {
+		if name != containerName {
+			used[registered] = true
+		}
+	}
+
+	for port = FirstSshPort; port <= lastSshPort; port++ {
+		if used[port] || !IsPortFree(port) {
+			continue
+		}
+		r.Set(containerName, port)
+		return port, nil
+	}
+	return 0, fmt.Errorf("no free port in range %d-%d", FirstSshPort, lastSshPort)
+}
+
+func (r *Registry) save() (err error) {
+	var data []byte
+	data, err = json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal ports registry: %w", err)
+	}
+
+	err = fp.WriteFileAtomic(r.path, data, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot save ports registry: %w", err)
+	}
+	return nil
+}
+
+// IsPortFree checks the TCP port can be listened on all host interfaces.
+func IsPortFree(port int) bool {
+	l, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
@@ -0,0 +1,89 @@
+package ports
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestAllocateReusesRegisteredPort(t *testing.T) {
+	registry := &Registry{Ports: map[string]int{}}
+
+	port, err := registry.Allocate("a")
+	if err != nil {
+		t.Fatalf("cannot allocate port: %s", err)
+	}
+	again, err := registry.Allocate("a")
+	if err != nil {
+		t.Fatalf("cannot allocate port again: %s", err)
+	}
+	if again != port {
+		t.Errorf("expected registered port %d to be reused, got %d", port, again)
+	}
+}
+
+func TestAllocateSkipsPortsOfOtherContainers(t *testing.T) {
+	registry := &Registry{Ports: map[string]int{}}
+
+	first, err := registry.Allocate("a")
+	if err != nil {
+		t.Fatalf("cannot allocate port: %s", err)
+	}
+	second, err := registry.Allocate("b")
+	if err != nil {
+		t.Fatalf("cannot allocate port: %s", err)
+	}
+	if second == first {
+		t.Errorf("port %d of container 'a' must not be allocated for 'b'", first)
+	}
+
+	registry.Delete("a")
+	third, err := registry.Allocate("c")
+	if err != nil {
+		t.Fatalf("cannot allocate port: %s", err)
+	}
+	if third != first {
+		t.Errorf("expected released port %d to be allocated again, got %d", first, third)
+	}
+}
+
+func TestUpdateRegistryConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), registryFileName)
+	const count = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			errs <- UpdateRegistry(path, func(registry *Registry) (err error) {
+				_, err = registry.Allocate(name)
+				return err
+			})
+		}(fmt.Sprintf("container-%d", i))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("cannot update registry: %s", err)
+		}
+	}
+
+	registry, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("cannot load registry: %s", err)
+	}
+	if len(registry.Ports) != count {
+		t.Fatalf("expected %d registered ports, got %v", count, registry.Ports)
+	}
+	owners := map[int]string{}
+	for name, port := range registry.Ports {
+		if owner, ok := owners[port]; ok {
+			t.Errorf("port %d is allocated for both '%s' and '%s'", port, owner, name)
+		}
+		owners[port] = name
+	}
+}
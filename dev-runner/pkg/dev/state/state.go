@@ -0,0 +1,40 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Dir returns dev-runner state dir, by default '~/.local/state/dev-runner'.
+func Dir() (dir string, err error) {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		var homeDir string
+		homeDir, err = os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot get user home dir: %w", err)
+		}
+		stateDir = filepath.Join(homeDir, ".local", "state")
+	}
+	return filepath.Join(stateDir, "dev-runner"), nil
+}
+
+// ContainerDir returns state dir of the container, e.g. to keep its SSH keys.
+func ContainerDir(containerName string) (dir string, err error) {
+	dir, err = Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "containers", containerName), nil
+}
+
+// KnownHostsPath returns path of the file with pinned dev containers host keys.
+func KnownHostsPath() (path string, err error) {
+	var dir string
+	dir, err = Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "known_hosts"), nil
+}
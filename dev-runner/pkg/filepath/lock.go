@@ -0,0 +1,60 @@
+package filepath
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+const lockFileSuffix = ".lock"
+
+// Lock locks the file next to path, since files written by WriteFileAtomic are replaced
+// and a lock taken on them would be lost. Shared locks are for readers, exclusive one is for writer.
+func Lock(path string, exclusive bool) (unlock func(), err error) {
+	err = MakePaths(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	lockPath := path + lockFileSuffix
+	var lockFile *os.File
+	lockFile, err = os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open lock file '%s': %w", lockPath, err)
+	}
+
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	err = syscall.Flock(int(lockFile.Fd()), how)
+	if err != nil {
+		_ = lockFile.Close()
+		return nil, fmt.Errorf("cannot lock file '%s': %w", lockPath, err)
+	}
+
+	// Closing the file releases the lock.
+	return func() { _ = lockFile.Close() }, nil
+}
+
+// WriteFileAtomic writes data into temporary file and renames it to path,
+// so readers never see partially written file.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	err = MakePaths(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	err = os.WriteFile(tmpPath, data, perm)
+	if err != nil {
+		return fmt.Errorf("cannot write file '%s': %w", tmpPath, err)
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return fmt.Errorf("cannot replace file '%s': %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package ssh
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+// defaultIdentityFiles are tried from '<homeDir>/.ssh' like OpenSSH client does.
+var defaultIdentityFiles = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+type AuthOptions struct {
+	// IdentityFiles are private keys which must be loaded.
+	IdentityFiles []string
+	// HomeDir is the dir with '.ssh' to load default private keys from.
+	HomeDir string
+	// UseAgent enables ssh-agent from 'SSH_AUTH_SOCK'.
+	UseAgent bool
+	// Password is used when all keys are rejected.
+	Password string
+}
+
+// GetAuthMethods returns public key authentication with all found keys and password as fallback.
+func GetAuthMethods(options AuthOptions) (authMethods []ssh.AuthMethod, err error) {
+	var signers []ssh.Signer
+	for _, path := range options.IdentityFiles {
+		var signer ssh.Signer
+		signer, err = loadSigner(path)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, signer)
+	}
+
+	if options.HomeDir != "" {
+		for _, name := range defaultIdentityFiles {
+			path := filepath.Join(options.HomeDir, ".ssh", name)
+			if !fp.IsFile(path) {
+				continue
+			}
+			signer, err := loadSigner(path)
+			if err != nil {
+				// Keys protected by passphrase are expected to be in ssh-agent.
+				continue
+			}
+			signers = append(signers, signer)
+		}
+	}
+
+	var agentClient agent.ExtendedAgent
+	if options.UseAgent {
+		agentClient = connectAgent()
+	}
+
+	if len(signers) > 0 || agentClient != nil {
+		// All keys must be in single method, because ssh client tries each method kind once.
+		authMethods = append(authMethods, ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+			if agentClient == nil {
+				return signers, nil
+			}
+			agentSigners, err := agentClient.Signers()
+			if err != nil {
+				return signers, nil
+			}
+			return append(signers, agentSigners...), nil
+		}))
+	}
+
+	if options.Password != "" {
+		authMethods = append(authMethods, ssh.Password(options.Password))
+	}
+
+	if len(authMethods) == 0 {
+		return nil, errors.New("no SSH authentication method available")
+	}
+	return authMethods, nil
+}
+
+func loadSigner(path string) (signer ssh.Signer, err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read private key '%s': %w", path, err)
+	}
+
+	signer, err = ssh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse private key '%s': %w", path, err)
+	}
+	return signer, nil
+}
+
+func connectAgent() agent.ExtendedAgent {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil
+	}
+
+	con, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil
+	}
+	return agent.NewClient(con)
+}
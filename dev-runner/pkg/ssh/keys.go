@@ -0,0 +1,77 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+const (
+	privateKeyFileName     = "id_ed25519"
+	authorizedKeysFileName = "authorized_keys"
+)
+
+type KeyPair struct {
+	PrivateKeyPath     string
+	AuthorizedKeysPath string
+}
+
+// KeyPairPaths returns paths of key pair files stored in the dir.
+func KeyPairPaths(dir string) KeyPair {
+	return KeyPair{
+		PrivateKeyPath:     filepath.Join(dir, privateKeyFileName),
+		AuthorizedKeysPath: filepath.Join(dir, authorizedKeysFileName),
+	}
+}
+
+// GetKeyPair returns ed25519 key pair stored in the dir, the pair is generated on first call.
+func GetKeyPair(dir string) (keyPair KeyPair, err error) {
+	keyPair = KeyPairPaths(dir)
+	if fp.IsFile(keyPair.PrivateKeyPath) && fp.IsFile(keyPair.AuthorizedKeysPath) {
+		return keyPair, nil
+	}
+
+	err = fp.MakePaths(dir)
+	if err != nil {
+		return KeyPair{}, err
+	}
+
+	var publicKey ed25519.PublicKey
+	var privateKey ed25519.PrivateKey
+	publicKey, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("cannot generate key: %w", err)
+	}
+
+	var privateKeyBlock *pem.Block
+	privateKeyBlock, err = ssh.MarshalPrivateKey(privateKey, "dev-runner")
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("cannot marshal private key: %w", err)
+	}
+
+	var sshPublicKey ssh.PublicKey
+	sshPublicKey, err = ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("cannot convert public key: %w", err)
+	}
+
+	err = os.WriteFile(keyPair.PrivateKeyPath, pem.EncodeToMemory(privateKeyBlock), 0o600)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("cannot write private key '%s': %w", keyPair.PrivateKeyPath, err)
+	}
+
+	// sshd reads authorized keys as the container user, so the file must be readable.
+	err = os.WriteFile(keyPair.AuthorizedKeysPath, ssh.MarshalAuthorizedKey(sshPublicKey), 0o644)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("cannot write authorized keys '%s': %w", keyPair.AuthorizedKeysPath, err)
+	}
+
+	return keyPair, nil
+}
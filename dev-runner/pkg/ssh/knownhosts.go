@@ -0,0 +1,165 @@
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	fp "dev-runner/pkg/filepath"
+)
+
+type knownHost struct {
+	containerName string
+	address       string
+	key           ssh.PublicKey
+}
+
+// KnownHosts pins host keys of dev containers keyed by container name and address.
+// File line format: '<container name> <[host]:port> <key type> <base64 key>'.
+type KnownHosts struct {
+	path  string
+	hosts []knownHost
+}
+
+// LoadKnownHosts reads known hosts, the file is locked for reading meanwhile.
+func LoadKnownHosts(path string) (knownHosts *KnownHosts, err error) {
+	var unlock func()
+	unlock, err = fp.Lock(path, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return load(path)
+}
+
+// UpdateKnownHosts reads known hosts, applies update to them and saves them. The file is locked
+// meanwhile, so concurrent attach and run do not overwrite each other pins.
+func UpdateKnownHosts(path string, update func(knownHosts *KnownHosts) error) (err error) {
+	var unlock func()
+	unlock, err = fp.Lock(path, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	var knownHosts *KnownHosts
+	knownHosts, err = load(path)
+	if err != nil {
+		return err
+	}
+
+	err = update(knownHosts)
+	if err != nil {
+		return err
+	}
+	return knownHosts.save()
+}
+
+func load(path string) (knownHosts *KnownHosts, err error) {
+	knownHosts = &KnownHosts{path: path}
+
+	var data []byte
+	data, err = os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return knownHosts, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read known hosts '%s': %w", path, err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("incorrect known hosts '%s' line %d", path, lineNumber)
+		}
+
+		var key ssh.PublicKey
+		key, _, _, _, err = ssh.ParseAuthorizedKey([]byte(fields[2]))
+		if err != nil {
+			return nil, fmt.Errorf("incorrect known hosts '%s' line %d key: %w", path, lineNumber, err)
+		}
+
+		knownHosts.hosts = append(knownHosts.hosts, knownHost{
+			containerName: fields[0],
+			address:       fields[1],
+			key:           key,
+		})
+	}
+	return knownHosts, scanner.Err()
+}
+
+// HostKeyCallback pins the host key on first connect to the container and refuses mismatches.
+// Pins are checked against the file as it is on connect, not as it was loaded.
+func (k *KnownHosts) HostKeyCallback(containerName string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) (err error) {
+		address := knownhosts.Normalize(hostname)
+
+		return UpdateKnownHosts(k.path, func(knownHosts *KnownHosts) error {
+			for _, host := range knownHosts.hosts {
+				if host.containerName != containerName || host.address != address {
+					continue
+				}
+				if bytes.Equal(host.key.Marshal(), key.Marshal()) {
+					return nil
+				}
+				return fmt.Errorf(
+					"host key of container '%s' at '%s' has changed: pinned %s, got %s; "+
+						"if the container was recreated, run 'stop' and 'run' again to rotate the key",
+					containerName,
+					address,
+					ssh.FingerprintSHA256(host.key),
+					ssh.FingerprintSHA256(key),
+				)
+			}
+
+			knownHosts.hosts = append(knownHosts.hosts, knownHost{
+				containerName: containerName,
+				address:       address,
+				key:           key,
+			})
+			return nil
+		})
+	}
+}
+
+// Forget removes pinned host keys of the container, so a new key is pinned on next connect.
+func (k *KnownHosts) Forget(containerName string) {
+	hosts := k.hosts[:0]
+	for _, host := range k.hosts {
+		if host.containerName != containerName {
+			hosts = append(hosts, host)
+		}
+	}
+	k.hosts = hosts
+}
+
+func (k *KnownHosts) save() (err error) {
+	b := strings.Builder{}
+	for _, host := range k.hosts {
+		b.WriteString(host.containerName)
+		b.WriteString(" ")
+		b.WriteString(host.address)
+		b.WriteString(" ")
+		b.Write(ssh.MarshalAuthorizedKey(host.key))
+	}
+
+	err = fp.WriteFileAtomic(k.path, []byte(b.String()), 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot save known hosts: %w", err)
+	}
+	return nil
+}
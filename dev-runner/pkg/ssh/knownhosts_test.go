@@ -0,0 +1,142 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate host key: %s", err)
+	}
+	key, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("cannot convert host key: %s", err)
+	}
+	return key
+}
+
+func checkHostKey(t *testing.T, path string, containerName string, key ssh.PublicKey) error {
+	t.Helper()
+
+	knownHosts, err := LoadKnownHosts(path)
+	if err != nil {
+		t.Fatalf("cannot load known hosts: %s", err)
+	}
+	remote := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 2222}
+	return knownHosts.HostKeyCallback(containerName)("localhost:2222", remote, key)
+}
+
+func TestHostKeyCallbackPinsKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	key := newHostKey(t)
+
+	err := checkHostKey(t, path, "a", key)
+	if err != nil {
+		t.Fatalf("first connect must pin the key: %s", err)
+	}
+	err = checkHostKey(t, path, "a", key)
+	if err != nil {
+		t.Errorf("pinned key must be accepted: %s", err)
+	}
+
+	err = checkHostKey(t, path, "a", newHostKey(t))
+	if err == nil || !strings.Contains(err.Error(), "has changed") {
+		t.Errorf("changed key must be refused, got %v", err)
+	}
+
+	err = checkHostKey(t, path, "b", newHostKey(t))
+	if err != nil {
+		t.Errorf("key of other container must be pinned apart: %s", err)
+	}
+}
+
+func TestForgetRotatesKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "known_hosts")
+
+	err := checkHostKey(t, path, "a", newHostKey(t))
+	if err != nil {
+		t.Fatalf("first connect must pin the key: %s", err)
+	}
+	err = checkHostKey(t, path, "b", newHostKey(t))
+	if err != nil {
+		t.Fatalf("first connect must pin the key: %s", err)
+	}
+
+	// The snapshot is loaded before the key is forgotten, as by attach running alongside run.
+	stale, err := LoadKnownHosts(path)
+	if err != nil {
+		t.Fatalf("cannot load known hosts: %s", err)
+	}
+
+	err = UpdateKnownHosts(path, func(knownHosts *KnownHosts) error {
+		knownHosts.Forget("a")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("cannot forget host key: %s", err)
+	}
+
+	remote := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 2222}
+	err = stale.HostKeyCallback("c")("localhost:2222", remote, newHostKey(t))
+	if err != nil {
+		t.Fatalf("first connect must pin the key: %s", err)
+	}
+
+	err = checkHostKey(t, path, "a", newHostKey(t))
+	if err != nil {
+		t.Errorf("new key must be pinned after forget: %s", err)
+	}
+}
+
+func TestHostKeyCallbackConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	const count = 20
+
+	keys := make([]ssh.PublicKey, count)
+	for i := range keys {
+		keys[i] = newHostKey(t)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			knownHosts, err := LoadKnownHosts(path)
+			if err != nil {
+				errs <- err
+				return
+			}
+			remote := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 2222}
+			errs <- knownHosts.HostKeyCallback(fmt.Sprintf("container-%d", i))("localhost:2222", remote, keys[i])
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("cannot pin host key: %s", err)
+		}
+	}
+
+	knownHosts, err := LoadKnownHosts(path)
+	if err != nil {
+		t.Fatalf("cannot load known hosts: %s", err)
+	}
+	if len(knownHosts.hosts) != count {
+		t.Errorf("expected %d pinned keys, got %d", count, len(knownHosts.hosts))
+	}
+}
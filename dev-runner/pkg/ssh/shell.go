@@ -9,10 +9,18 @@ import (
 	"golang.org/x/crypto/ssh"
 )
 
-func RunShell(host string, port int, user string, password string) (err error) {
-	con := &sshlib.Connect{ForwardX11: true}
-	auth := sshlib.CreateAuthMethodPassword(password)
-	err = con.CreateClient(host, strconv.Itoa(port), user, []ssh.AuthMethod{auth})
+func RunShell(
+	host string,
+	port int,
+	user string,
+	authMethods []ssh.AuthMethod,
+	hostKeyCallback ssh.HostKeyCallback,
+) (err error) {
+	con := &sshlib.Connect{
+		ForwardX11:      true,
+		HostKeyCallback: hostKeyCallback,
+	}
+	err = con.CreateClient(host, strconv.Itoa(port), user, authMethods)
 	if err != nil {
 		return fmt.Errorf("cannot create ssh client: %w", err)
 	}
@@ -20,12 +28,12 @@ func RunShell(host string, port int, user string, password string) (err error) {
 	var session *ssh.Session
 	session, err = con.CreateSession()
 	if err != nil {
-		return fmt.Errorf("cannot create ssh session to '%s@%s:%p': %w", user, host, port, err)
+		return fmt.Errorf("cannot create ssh session to '%s@%s:%d': %w", user, host, port, err)
 	}
 
 	err = con.Shell(session)
 	if err != nil {
-		return fmt.Errorf("cannot run ssh shell on '%s@%s:%p': %w", user, host, port, err)
+		return fmt.Errorf("cannot run ssh shell on '%s@%s:%d': %w", user, host, port, err)
 	}
 
 	return nil
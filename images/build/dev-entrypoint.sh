@@ -0,0 +1,39 @@
+#!/bin/bash
+# Adjusts the container user to the host user ids passed by dev-runner,
+# so files created in bind mounts keep host ownership, and generates SSH host keys of the container.
+
+set -e
+
+user_name=user
+
+# Mapping the user to root would give root ownership of its home to the host user, so it is refused.
+if [ "${DEV_CONTAINER_USER_UID}" = "0" ]; then
+  echo "dev-entrypoint: host user is root, user ids are not remapped" >&2
+  unset DEV_CONTAINER_USER_UID DEV_CONTAINER_USER_GID
+fi
+
+if [ -n "${DEV_CONTAINER_USER_GID}" ] && [ "${DEV_CONTAINER_USER_GID}" != "$(id -g "${user_name}")" ]; then
+  if ! getent group "${DEV_CONTAINER_USER_GID}" > /dev/null; then
+    groupadd -g "${DEV_CONTAINER_USER_GID}" host
+  fi
+  old_gid="$(id -g "${user_name}")"
+  usermod -g "${DEV_CONTAINER_USER_GID}" "${user_name}"
+  find "/home/${user_name}" -xdev -group "${old_gid}" -exec chgrp -h "${DEV_CONTAINER_USER_GID}" {} +
+fi
+
+if [ -n "${DEV_CONTAINER_USER_UID}" ] && [ "${DEV_CONTAINER_USER_UID}" != "$(id -u "${user_name}")" ]; then
+  old_uid="$(id -u "${user_name}")"
+  usermod -o -u "${DEV_CONTAINER_USER_UID}" "${user_name}"
+  find "/home/${user_name}" -xdev -user "${old_uid}" -exec chown -h "${DEV_CONTAINER_USER_UID}" {} +
+fi
+
+# Host keys are generated once per container, as the ones baked into the image are shared by all its containers.
+# The marker keeps keys of the container across restarts, so the pinned key stays valid.
+host_keys_marker=/etc/ssh/.dev-container-host-keys
+if [ ! -f "${host_keys_marker}" ]; then
+  rm -f /etc/ssh/ssh_host_*
+  ssh-keygen -A
+  touch "${host_keys_marker}"
+fi
+
+exec "$@"